@@ -1,21 +1,137 @@
 package main
 
 import (
+	"context"
+	"flag"
+	"fmt"
 	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/adapter/cli"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/adapter/filesystem"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/adapter/git"
 	"github.com/rinsyan0518/wpks-ls/internal/pkg/adapter/inmemory"
 	"github.com/rinsyan0518/wpks-ls/internal/pkg/adapter/lsp"
 	"github.com/rinsyan0518/wpks-ls/internal/pkg/adapter/packwerk"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/adapter/packwerk/config"
 	"github.com/rinsyan0518/wpks-ls/internal/pkg/usecase"
 )
 
+const usage = `Usage: wpks-ls [command]
+
+Commands:
+  serve             Starts the language server on stdio (default), or with --listen on TCP or WebSocket
+  check [paths...]  Checks the files, or the whole project, and exits with 1 when violations are found
+  baseline          Lists the current violations in .wpks-ls-baseline.json, so that only new ones fail check
+
+Run 'wpks-ls <command> --help' for the options of a command.
+`
+
 func main() {
-	workspaceRepository := inmemory.NewWorkspaceRepository()
-	diagnoseFile := usecase.NewDiagnoseFile(workspaceRepository, packwerk.NewRunnerWithDefaultCheckers())
-	createWorkspace := usecase.NewCreateWorkspace(workspaceRepository)
-	server := lsp.NewServer(diagnoseFile, createWorkspace)
-	err := server.Start()
+	command, args := "serve", os.Args[1:]
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		command, args = args[0], args[1:]
+	}
+
+	switch command {
+	case "serve":
+		serve(args)
+	case "check":
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		code := cli.NewCheckCommand(newCheckUsecases(), os.Stdout, os.Stderr).Run(ctx, args)
+		stop()
+		os.Exit(code)
+	case "baseline":
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		code := cli.NewBaselineCommand(newCheckUsecases(), os.Stdout, os.Stderr).Run(ctx, args)
+		stop()
+		os.Exit(code)
+	case "help":
+		fmt.Print(usage)
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", command, usage)
+		os.Exit(cli.ExitError)
+	}
+}
+
+func serve(args []string) {
+	flags := flag.NewFlagSet("serve", flag.ExitOnError)
+	listen := flags.String("listen", "", "tcp://host:port or ws://host:port[/path] to accept clients on, instead of stdio")
+	allowRemote := flags.Bool("allow-remote", false, "allow --listen on hosts other than loopback. Clients are not authenticated")
+	flags.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: wpks-ls serve [--listen ADDRESS [--allow-remote]]")
+		fmt.Fprintln(os.Stderr)
+		flags.PrintDefaults()
+	}
+	_ = flags.Parse(args)
+
+	if *listen == "" {
+		server := lsp.NewServer(newUsecases())
+		err := server.Start()
+		if err != nil {
+			log.Fatalf("failed to start LSP server: %v", err)
+		}
+		return
+	}
+
+	// Every client gets a workspace of its own, inside the directory the server was started in
+	workingDirectory, err := os.Getwd()
+	if err != nil {
+		log.Fatalf("failed to read the working directory: %v", err)
+	}
+	listener, err := lsp.NewListener(*listen, *allowRemote, workingDirectory, newUsecases)
 	if err != nil {
-		log.Fatalf("failed to start LSP server: %v", err)
+		log.Fatalf("failed to listen: %v", err)
+	}
+	log.Printf("listening for clients on %s", listener.Addr())
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if err := listener.Serve(ctx); err != nil {
+		log.Fatalf("failed to serve: %v", err)
+	}
+}
+
+func newCheckUsecases() cli.Usecases {
+	usecases := newUsecases()
+	return cli.Usecases{
+		CreateWorkspace:   usecases.CreateWorkspace,
+		LoadPackages:      usecases.LoadPackages,
+		ConfigureSettings: usecases.ConfigureSettings,
+		DiagnoseFile:      usecases.DiagnoseFile,
+		ManageBaseline:    usecases.ManageBaseline,
+	}
+}
+
+func newUsecases() lsp.Usecases {
+	workspaceRepository := inmemory.NewWorkspaceRepository()
+	packageRepository := inmemory.NewPackageRepository()
+	documentRepository := inmemory.NewDocumentRepository()
+	violationRepository := inmemory.NewViolationRepository()
+	settingsRepository := inmemory.NewSettingsRepository()
+	configReader := config.NewReader()
+	yamlParser := config.NewYamlParser()
+	packwerkRunner := packwerk.NewRunnerWithDefaultCheckers()
+	fileSystem := filesystem.NewFileSystem()
+	versionControl := git.NewVersionControl()
+	return lsp.Usecases{
+		DiagnoseFile:        usecase.NewDiagnoseFile(workspaceRepository, packageRepository, violationRepository, settingsRepository, configReader, fileSystem, versionControl, packwerkRunner),
+		CreateWorkspace:     usecase.NewCreateWorkspace(workspaceRepository),
+		LoadPackages:        usecase.NewLoadPackages(workspaceRepository, packageRepository, configReader),
+		ResolvePackage:      usecase.NewResolvePackage(workspaceRepository, packageRepository),
+		SyncDocument:        usecase.NewSyncDocument(documentRepository),
+		FindDefinition:      usecase.NewFindDefinition(workspaceRepository, packageRepository, documentRepository, yamlParser),
+		FindReferences:      usecase.NewFindReferences(workspaceRepository, packageRepository, documentRepository, yamlParser, configReader),
+		ShowHover:           usecase.NewShowHover(workspaceRepository, packageRepository, documentRepository, violationRepository, settingsRepository),
+		Complete:            usecase.NewComplete(workspaceRepository, packageRepository, documentRepository),
+		ValidatePackage:     usecase.NewValidatePackage(workspaceRepository, packageRepository, documentRepository, yamlParser, configReader),
+		SearchSymbols:       usecase.NewSearchSymbols(workspaceRepository, packageRepository),
+		ListDocumentSymbols: usecase.NewListDocumentSymbols(documentRepository, yamlParser),
+		ManageChecker:       usecase.NewManageChecker(workspaceRepository, packageRepository, settingsRepository, packwerkRunner, fileSystem),
+		ManageBaseline:      usecase.NewManageBaseline(workspaceRepository, violationRepository, settingsRepository, fileSystem, packwerkRunner),
+		ListCodeLenses:      usecase.NewListCodeLenses(workspaceRepository, packageRepository, violationRepository, settingsRepository, configReader, fileSystem, versionControl),
+		ConfigureSettings:   usecase.NewConfigureSettings(workspaceRepository, settingsRepository, packwerkRunner, fileSystem, yamlParser),
 	}
 }
@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+)
+
+// BaselineCommand writes .wpks-ls-baseline.json, so that check only fails on new violations.
+type BaselineCommand struct {
+	usecases Usecases
+	stdout   io.Writer
+	stderr   io.Writer
+}
+
+func NewBaselineCommand(usecases Usecases, stdout io.Writer, stderr io.Writer) *BaselineCommand {
+	return &BaselineCommand{usecases: usecases, stdout: stdout, stderr: stderr}
+}
+
+// Run checks the whole project and creates the baseline, or refreshes it with --refresh.
+func (c *BaselineCommand) Run(ctx context.Context, args []string) int {
+	flags := flag.NewFlagSet("baseline", flag.ContinueOnError)
+	flags.SetOutput(c.stderr)
+	root := flags.String("root", ".", "root of the project, where packwerk.yml is")
+	refresh := flags.Bool("refresh", false, "drop the violations fixed since the baseline was created, without adding new ones")
+	flags.Usage = func() {
+		fmt.Fprintln(c.stderr, "Usage: wpks-ls baseline [--root DIR] [--refresh]")
+		fmt.Fprintln(c.stderr)
+		fmt.Fprintf(c.stderr, "Checks the whole project and lists the violations in %s.\n", domain.BaselineFile)
+		fmt.Fprintln(c.stderr, "Listed violations do not fail check, and the editor shows them as hints by default.")
+		fmt.Fprintln(c.stderr)
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return ExitOK
+		}
+		return ExitError
+	}
+	if flags.NArg() > 0 {
+		return c.fail("unexpected arguments: %v", flags.Args())
+	}
+
+	rootPath, err := filepath.Abs(*root)
+	if err != nil {
+		return c.fail("invalid root: %v", err)
+	}
+	if err := requirePackwerkConfig(rootPath); err != nil {
+		return c.fail("%v", err)
+	}
+	if err := c.usecases.CreateWorkspace.Create(fileUri(rootPath), rootPath); err != nil {
+		return c.fail("failed to create the workspace: %v", err)
+	}
+	if err := c.usecases.LoadPackages.Load(); err != nil {
+		fmt.Fprintf(c.stderr, "warning: failed to load packages: %v\n", err)
+	}
+	if _, err := configure(c.usecases, c.stderr, ""); err != nil {
+		return c.fail("invalid settings: %v", err)
+	}
+
+	update, verb := c.usecases.ManageBaseline.CreateBaseline, "Created"
+	if *refresh {
+		update, verb = c.usecases.ManageBaseline.RefreshBaseline, "Refreshed"
+	}
+	count, err := update(ctx)
+	if err != nil {
+		return c.fail("failed to update %s: %v", domain.BaselineFile, err)
+	}
+	fmt.Fprintf(c.stdout, "%s %s with %d known violations\n", verb, domain.BaselineFile, count)
+	return ExitOK
+}
+
+func (c *BaselineCommand) fail(format string, args ...any) int {
+	fmt.Fprintf(c.stderr, "error: "+format+"\n", args...)
+	return ExitError
+}
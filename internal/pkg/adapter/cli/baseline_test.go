@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestBaselineCommand_Run(t *testing.T) {
+	tests := []struct {
+		name         string
+		args         []string
+		baselineErr  error
+		wantCode     int
+		wantBaseline string
+		wantStdout   string
+		wantStderr   string
+	}{
+		{
+			name:         "create",
+			wantCode:     ExitOK,
+			wantBaseline: "created",
+			wantStdout:   "Created .wpks-ls-baseline.json with 3 known violations\n",
+		},
+		{
+			name:         "refresh",
+			args:         []string{"--refresh"},
+			wantCode:     ExitOK,
+			wantBaseline: "refreshed",
+			wantStdout:   "Refreshed .wpks-ls-baseline.json with 1 known violations\n",
+		},
+		{
+			name:         "failed check",
+			baselineErr:  errors.New("no checker command succeeded"),
+			wantCode:     ExitError,
+			wantBaseline: "created",
+			wantStderr:   "error: failed to update .wpks-ls-baseline.json: no checker command succeeded",
+		},
+		{
+			name:       "paths",
+			args:       []string{"packs/users"},
+			wantCode:   ExitError,
+			wantStderr: "error: unexpected arguments: [packs/users]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fakeUsecases{baselineErr: tt.baselineErr}
+			stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+			cmd := NewBaselineCommand(newTestUsecases(f), stdout, stderr)
+
+			code := cmd.Run(context.Background(), append([]string{"--root", packwerkRoot(t)}, tt.args...))
+			if code != tt.wantCode {
+				t.Errorf("want exit code %d, got %d", tt.wantCode, code)
+			}
+			if f.baseline != tt.wantBaseline {
+				t.Errorf("want the baseline %q, got %q", tt.wantBaseline, f.baseline)
+			}
+			if stdout.String() != tt.wantStdout {
+				t.Errorf("want %q, got %q", tt.wantStdout, stdout.String())
+			}
+			if !strings.Contains(stderr.String(), tt.wantStderr) {
+				t.Errorf("want %q in %q", tt.wantStderr, stderr.String())
+			}
+		})
+	}
+}
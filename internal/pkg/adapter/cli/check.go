@@ -0,0 +1,191 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/adapter/report"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/port/in"
+)
+
+// Exit codes of the commands
+const (
+	ExitOK         = 0
+	ExitViolations = 1 // violations were reported
+	ExitError      = 2 // the command could not run
+)
+
+// Usecases bundles the input ports the commands use.
+type Usecases struct {
+	CreateWorkspace   in.CreateWorkspace
+	LoadPackages      in.LoadPackages
+	ConfigureSettings in.ConfigureSettings
+	DiagnoseFile      in.DiagnoseFile
+	ManageBaseline    in.ManageBaseline
+}
+
+// CheckCommand runs the checks of the language server without an editor,
+// e.g. from a pre-commit hook. It reads .wpks-ls.yml like the server does.
+type CheckCommand struct {
+	usecases Usecases
+	stdout   io.Writer
+	stderr   io.Writer
+}
+
+func NewCheckCommand(usecases Usecases, stdout io.Writer, stderr io.Writer) *CheckCommand {
+	return &CheckCommand{usecases: usecases, stdout: stdout, stderr: stderr}
+}
+
+// Run checks the paths, or the whole project without paths, and prints the violations.
+// It returns ExitViolations when any violation is reported.
+func (c *CheckCommand) Run(ctx context.Context, args []string) int {
+	flags := flag.NewFlagSet("check", flag.ContinueOnError)
+	flags.SetOutput(c.stderr)
+	root := flags.String("root", ".", "root of the project, where packwerk.yml is")
+	format := flags.String("format", "text", "output format: "+strings.Join(report.Formats(), ", "))
+	diffBase := flags.String("diff-base", "", "git revision, e.g. origin/main, to only report violations on the lines changed since")
+	flags.Usage = func() {
+		fmt.Fprintln(c.stderr, "Usage: wpks-ls check [--root DIR] [--format FORMAT] [--diff-base REF] [paths...]")
+		fmt.Fprintln(c.stderr)
+		fmt.Fprintln(c.stderr, "Checks the files, or the whole project without paths, and exits with 1 when violations are found.")
+		fmt.Fprintln(c.stderr, "With a diff base and without paths, only the changed files are checked.")
+		fmt.Fprintln(c.stderr)
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return ExitOK
+		}
+		return ExitError
+	}
+	reporter, err := report.NewReporter(*format)
+	if err != nil {
+		return c.fail("%v", err)
+	}
+
+	rootPath, err := filepath.Abs(*root)
+	if err != nil {
+		return c.fail("invalid root: %v", err)
+	}
+	if err := requirePackwerkConfig(rootPath); err != nil {
+		return c.fail("%v", err)
+	}
+	uris, err := fileUris(rootPath, flags.Args())
+	if err != nil {
+		return c.fail("%v", err)
+	}
+
+	if err := c.usecases.CreateWorkspace.Create(fileUri(rootPath), rootPath); err != nil {
+		return c.fail("failed to create the workspace: %v", err)
+	}
+	if err := c.usecases.LoadPackages.Load(); err != nil {
+		fmt.Fprintf(c.stderr, "warning: failed to load packages: %v\n", err)
+	}
+	settings, err := configure(c.usecases, c.stderr, *diffBase)
+	if err != nil {
+		return c.fail("invalid settings: %v", err)
+	}
+	if err := c.usecases.ManageBaseline.ValidateBaseline(); err != nil {
+		fmt.Fprintf(c.stderr, "warning: %v, every violation is reported as new\n", err)
+	}
+
+	var diagnosticsByFile map[string][]domain.Diagnostic
+	switch {
+	case len(uris) == 0 && settings.DiffBase != "":
+		diagnosticsByFile, err = c.usecases.DiagnoseFile.DiagnoseChanged(ctx)
+	case len(uris) == 0:
+		diagnosticsByFile, err = c.usecases.DiagnoseFile.DiagnoseAll(ctx)
+	default:
+		diagnosticsByFile, err = c.usecases.DiagnoseFile.Diagnose(ctx, uris...)
+	}
+	if err != nil {
+		return c.fail("check failed: %v", err)
+	}
+
+	results := collectResults(diagnosticsByFile)
+	if err := report.Write(c.stdout, reporter, results); err != nil {
+		return c.fail("failed to write the report: %v", err)
+	}
+	switch count := len(results); count {
+	case 0:
+		fmt.Fprintln(c.stderr, "No violations found")
+		return ExitOK
+	case 1:
+		fmt.Fprintln(c.stderr, "1 violation found")
+	default:
+		fmt.Fprintf(c.stderr, "%d violations found\n", count)
+	}
+	return ExitViolations
+}
+
+// configure applies .wpks-ls.yml, and the diff base when given. Invalid entries are reported and skipped, as in the editor.
+func configure(usecases Usecases, stderr io.Writer, diffBase string) (domain.Settings, error) {
+	settings, settingsErrors, err := usecases.ConfigureSettings.ProjectSettings()
+	if err != nil {
+		fmt.Fprintf(stderr, "warning: failed to read %s: %v\n", domain.ProjectSettingsFile, err)
+	}
+	for _, settingsError := range settingsErrors {
+		fmt.Fprintf(stderr, "warning: %s:%d: %s\n", domain.ProjectSettingsFile, settingsError.Range.Start.Line+1, settingsError.Message)
+	}
+	if diffBase != "" {
+		settings.DiffBase = diffBase
+	}
+	return settings, usecases.ConfigureSettings.Configure(settings)
+}
+
+// collectResults keeps the diagnostics reporting a new violation.
+// Violations listed in the baseline do not fail the check.
+func collectResults(diagnosticsByFile map[string][]domain.Diagnostic) []report.Result {
+	var results []report.Result
+	for _, diagnostics := range diagnosticsByFile {
+		for _, d := range diagnostics {
+			if d.Violation == nil || d.Known {
+				continue
+			}
+			results = append(results, report.Result{Violation: *d.Violation, Severity: d.Severity, Message: d.Message})
+		}
+	}
+	return results
+}
+
+// requirePackwerkConfig fails the commands outside of a packwerk project,
+// where packwerk checks nothing and every check would pass.
+func requirePackwerkConfig(rootPath string) error {
+	if _, err := os.Stat(filepath.Join(rootPath, domain.PackwerkConfigFile)); err != nil {
+		return fmt.Errorf("%s not found in %s", domain.PackwerkConfigFile, rootPath)
+	}
+	return nil
+}
+
+func (c *CheckCommand) fail(format string, args ...any) int {
+	fmt.Fprintf(c.stderr, "error: "+format+"\n", args...)
+	return ExitError
+}
+
+// fileUris converts the paths given on the command line, relative to the working directory.
+func fileUris(rootPath string, paths []string) ([]string, error) {
+	uris := make([]string, 0, len(paths))
+	for _, path := range paths {
+		absolutePath, err := filepath.Abs(path)
+		if err != nil {
+			return nil, fmt.Errorf("invalid path %s: %v", path, err)
+		}
+		relativePath, err := filepath.Rel(rootPath, absolutePath)
+		if err != nil || relativePath == ".." || strings.HasPrefix(relativePath, ".."+string(filepath.Separator)) {
+			return nil, fmt.Errorf("%s is outside of the project %s", path, rootPath)
+		}
+		uris = append(uris, fileUri(absolutePath))
+	}
+	return uris, nil
+}
+
+func fileUri(path string) string {
+	return "file://" + filepath.ToSlash(path)
+}
@@ -0,0 +1,289 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+)
+
+type fakeUsecases struct {
+	rootUri          string
+	diagnosed        []string
+	diagnosedAll     bool
+	diagnosedChanged bool
+	baseline         string // "created" or "refreshed"
+	baselineErr      error
+	diagnoseErr      error
+	invalidBaseline  error
+	configured       *domain.Settings
+	settingsErrors   []domain.SettingsError
+	diagnostics      map[string][]domain.Diagnostic
+}
+
+func (f *fakeUsecases) Create(rootUri string, rootPath string) error {
+	f.rootUri = rootUri
+	return nil
+}
+
+func (f *fakeUsecases) Load() error                  { return nil }
+func (f *fakeUsecases) Refresh(uris ...string) error { return nil }
+
+func (f *fakeUsecases) ProjectSettings() (domain.Settings, []domain.SettingsError, error) {
+	return domain.NewSettings(), f.settingsErrors, nil
+}
+
+func (f *fakeUsecases) Configure(settings domain.Settings) error {
+	f.configured = &settings
+	return nil
+}
+
+func (f *fakeUsecases) Diagnose(ctx context.Context, uris ...string) (map[string][]domain.Diagnostic, error) {
+	f.diagnosed = uris
+	return f.diagnostics, nil
+}
+
+func (f *fakeUsecases) DiagnoseAll(ctx context.Context) (map[string][]domain.Diagnostic, error) {
+	f.diagnosedAll = true
+	return f.diagnostics, f.diagnoseErr
+}
+
+func (f *fakeUsecases) DiagnoseChanged(ctx context.Context) (map[string][]domain.Diagnostic, error) {
+	f.diagnosedChanged = true
+	return f.diagnostics, nil
+}
+
+func (f *fakeUsecases) Rebuild() (map[string][]domain.Diagnostic, error) {
+	return f.diagnostics, nil
+}
+
+func (f *fakeUsecases) CreateBaseline(ctx context.Context) (int, error) {
+	f.baseline = "created"
+	return 3, f.baselineErr
+}
+
+func (f *fakeUsecases) RefreshBaseline(ctx context.Context) (int, error) {
+	f.baseline = "refreshed"
+	return 1, f.baselineErr
+}
+
+func (f *fakeUsecases) ValidateBaseline() error {
+	return f.invalidBaseline
+}
+
+// packwerkRoot creates a project with a packwerk.yml.
+func packwerkRoot(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, domain.PackwerkConfigFile), []byte(""), 0o644); err != nil {
+		t.Fatalf("failed to write packwerk.yml: %v", err)
+	}
+	return root
+}
+
+func newTestUsecases(f *fakeUsecases) Usecases {
+	return Usecases{CreateWorkspace: f, LoadPackages: f, ConfigureSettings: f, DiagnoseFile: f, ManageBaseline: f}
+}
+
+func newTestCommand(f *fakeUsecases) (*CheckCommand, *bytes.Buffer, *bytes.Buffer) {
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	return NewCheckCommand(newTestUsecases(f), stdout, stderr), stdout, stderr
+}
+
+func diagnosticAt(file string, line, character uint32, severity int32, message string) domain.Diagnostic {
+	v := domain.Violation{File: file, Line: line, Character: character, Type: "Dependency violation", Message: "Dependency violation: " + message}
+	return domain.Diagnostic{
+		Range:     v.Range(),
+		Severity:  severity,
+		Code:      v.Kind(),
+		Message:   message,
+		Violation: &v,
+	}
+}
+
+func TestCheckCommand_Run(t *testing.T) {
+	root := packwerkRoot(t)
+	rootUri := "file://" + filepath.ToSlash(root)
+	f := &fakeUsecases{diagnostics: map[string][]domain.Diagnostic{
+		rootUri + "/packs/users/b.rb": {diagnosticAt("packs/users/b.rb", 5, 2, domain.SeverityWarning, "::Book → packs/books")},
+		rootUri + "/packs/users/a.rb": {
+			diagnosticAt("packs/users/a.rb", 10, 0, domain.SeverityError, "second"),
+			diagnosticAt("packs/users/a.rb", 2, 6, domain.SeverityError, "first"),
+		},
+		rootUri + "/packs/users/c.rb": {},
+	}}
+	cmd, stdout, stderr := newTestCommand(f)
+
+	code := cmd.Run(context.Background(), []string{"--root", root})
+	if code != ExitViolations {
+		t.Errorf("want exit code %d, got %d", ExitViolations, code)
+	}
+	if !f.diagnosedAll || f.rootUri != rootUri || f.configured == nil {
+		t.Errorf("want the whole project checked with the settings applied, got %+v", f)
+	}
+	want := "packs/users/a.rb:2:6: error: first [dependency]\n" +
+		"packs/users/a.rb:10:0: error: second [dependency]\n" +
+		"packs/users/b.rb:5:2: warning: ::Book → packs/books [dependency]\n"
+	if stdout.String() != want {
+		t.Errorf("unexpected output:\n--- got ---\n%s\n--- want ---\n%s", stdout.String(), want)
+	}
+	if !strings.Contains(stderr.String(), "3 violations found") {
+		t.Errorf("want a summary, got %q", stderr.String())
+	}
+}
+
+func TestCheckCommand_Run_Paths(t *testing.T) {
+	root := packwerkRoot(t)
+	f := &fakeUsecases{diagnostics: map[string][]domain.Diagnostic{}}
+	cmd, _, stderr := newTestCommand(f)
+
+	code := cmd.Run(context.Background(), []string{"--root", root, filepath.Join(root, "packs/users/a.rb")})
+	if code != ExitOK {
+		t.Errorf("want exit code %d, got %d", ExitOK, code)
+	}
+	want := []string{"file://" + filepath.ToSlash(root) + "/packs/users/a.rb"}
+	if !reflect.DeepEqual(f.diagnosed, want) {
+		t.Errorf("want %v checked, got %v", want, f.diagnosed)
+	}
+	if !strings.Contains(stderr.String(), "No violations found") {
+		t.Errorf("want a summary, got %q", stderr.String())
+	}
+}
+
+func TestCheckCommand_Run_Errors(t *testing.T) {
+	root := packwerkRoot(t)
+	tests := []struct {
+		name    string
+		args    []string
+		want    int
+		wantErr string
+	}{
+		{"path outside of the project", []string{"--root", root, filepath.Dir(root)}, ExitError, "is outside of the project"},
+		{"unknown flag", []string{"--unknown"}, ExitError, "flag provided but not defined"},
+		{"unknown format", []string{"--format", "xml"}, ExitError, `unknown format "xml". Expected one of github, json, junit, sarif, text`},
+		{"help", []string{"--help"}, ExitOK, "Usage: wpks-ls check"},
+		{"not a packwerk project", []string{"--root", t.TempDir()}, ExitError, "packwerk.yml not found in"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fakeUsecases{}
+			cmd, _, stderr := newTestCommand(f)
+			if code := cmd.Run(context.Background(), tt.args); code != tt.want {
+				t.Errorf("want exit code %d, got %d", tt.want, code)
+			}
+			if !strings.Contains(stderr.String(), tt.wantErr) {
+				t.Errorf("want %q in %q", tt.wantErr, stderr.String())
+			}
+			if f.diagnosed != nil || f.diagnosedAll {
+				t.Error("want nothing checked")
+			}
+		})
+	}
+}
+
+func TestCheckCommand_Run_CheckerFailure(t *testing.T) {
+	f := &fakeUsecases{diagnoseErr: errors.New("packwerk check failed: exit status 1: uninitialized constant Packwerk")}
+	cmd, stdout, stderr := newTestCommand(f)
+
+	if code := cmd.Run(context.Background(), []string{"--root", packwerkRoot(t)}); code != ExitError {
+		t.Errorf("want exit code %d, got %d", ExitError, code)
+	}
+	if want := "error: check failed: packwerk check failed"; !strings.Contains(stderr.String(), want) {
+		t.Errorf("want %q in %q", want, stderr.String())
+	}
+	if stdout.Len() != 0 || strings.Contains(stderr.String(), "No violations found") {
+		t.Errorf("want no report of a failed check, got %q and %q", stdout.String(), stderr.String())
+	}
+}
+
+func TestCheckCommand_Run_SettingsErrors(t *testing.T) {
+	f := &fakeUsecases{
+		settingsErrors: []domain.SettingsError{{Range: domain.Range{Start: domain.Position{Line: 2}}, Message: "Unknown setting 'severty'."}},
+	}
+	cmd, _, stderr := newTestCommand(f)
+
+	if code := cmd.Run(context.Background(), []string{"--root", packwerkRoot(t)}); code != ExitOK {
+		t.Errorf("want exit code %d, got %d", ExitOK, code)
+	}
+	if want := "warning: .wpks-ls.yml:3: Unknown setting 'severty'."; !strings.Contains(stderr.String(), want) {
+		t.Errorf("want %q in %q", want, stderr.String())
+	}
+}
+
+func TestCheckCommand_Run_Sarif(t *testing.T) {
+	root := packwerkRoot(t)
+	rootUri := "file://" + filepath.ToSlash(root)
+	f := &fakeUsecases{diagnostics: map[string][]domain.Diagnostic{
+		rootUri + "/packs/users/a.rb": {diagnosticAt("packs/users/a.rb", 2, 6, domain.SeverityError, "::Book → packs/books")},
+	}}
+	cmd, stdout, _ := newTestCommand(f)
+
+	if code := cmd.Run(context.Background(), []string{"--root", root, "--format", "sarif"}); code != ExitViolations {
+		t.Errorf("want exit code %d, got %d", ExitViolations, code)
+	}
+	var log struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			Results []struct {
+				RuleID string `json:"ruleId"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &log); err != nil {
+		t.Fatalf("want a JSON log, got %v:\n%s", err, stdout.String())
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 || len(log.Runs[0].Results) != 1 || log.Runs[0].Results[0].RuleID != "dependency" {
+		t.Errorf("unexpected log: %+v", log)
+	}
+}
+
+func TestCheckCommand_Run_DiffBase(t *testing.T) {
+	f := &fakeUsecases{diagnostics: map[string][]domain.Diagnostic{}}
+	cmd, _, _ := newTestCommand(f)
+
+	if code := cmd.Run(context.Background(), []string{"--root", packwerkRoot(t), "--diff-base", "origin/main"}); code != ExitOK {
+		t.Errorf("want exit code %d, got %d", ExitOK, code)
+	}
+	if !f.diagnosedChanged || f.diagnosedAll {
+		t.Error("want only the changed files checked")
+	}
+	if f.configured == nil || f.configured.DiffBase != "origin/main" {
+		t.Errorf("want the diff base applied to the settings, got %+v", f.configured)
+	}
+}
+
+func TestCheckCommand_Run_Baseline(t *testing.T) {
+	root := packwerkRoot(t)
+	known := diagnosticAt("packs/users/a.rb", 2, 6, domain.SeverityHint, "known")
+	known.Known = true
+	f := &fakeUsecases{diagnostics: map[string][]domain.Diagnostic{
+		"file://" + filepath.ToSlash(root) + "/packs/users/a.rb": {known},
+	}}
+	cmd, stdout, stderr := newTestCommand(f)
+
+	if code := cmd.Run(context.Background(), []string{"--root", root}); code != ExitOK {
+		t.Errorf("want exit code %d, got %d", ExitOK, code)
+	}
+	if stdout.Len() != 0 || !strings.Contains(stderr.String(), "No violations found") {
+		t.Errorf("want the known violation left out, got %q and %q", stdout.String(), stderr.String())
+	}
+}
+
+func TestCheckCommand_Run_InvalidBaseline(t *testing.T) {
+	f := &fakeUsecases{invalidBaseline: errors.New("invalid .wpks-ls-baseline.json: unexpected end of JSON input")}
+	cmd, _, stderr := newTestCommand(f)
+
+	if code := cmd.Run(context.Background(), []string{"--root", packwerkRoot(t)}); code != ExitOK {
+		t.Errorf("want exit code %d, got %d", ExitOK, code)
+	}
+	if want := "warning: invalid .wpks-ls-baseline.json: unexpected end of JSON input"; !strings.Contains(stderr.String(), want) {
+		t.Errorf("want %q in %q", want, stderr.String())
+	}
+}
@@ -0,0 +1,38 @@
+package filesystem
+
+import (
+	"os"
+
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/port/out"
+)
+
+type FileSystem struct{}
+
+func NewFileSystem() *FileSystem {
+	return &FileSystem{}
+}
+
+func (f *FileSystem) ReadFile(path string) (string, bool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+	return string(data), true, nil
+}
+
+func (f *FileSystem) WriteFile(path string, text string) error {
+	return os.WriteFile(path, []byte(text), 0o644)
+}
+
+// RemoveFile removes the file. A missing file is not an error.
+func (f *FileSystem) RemoveFile(path string) error {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+var _ out.FileSystem = (*FileSystem)(nil)
@@ -0,0 +1,30 @@
+package filesystem
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestFileSystem(t *testing.T) {
+	fs := NewFileSystem()
+	path := filepath.Join(t.TempDir(), "package_todo.yml")
+
+	if _, exists, err := fs.ReadFile(path); err != nil || exists {
+		t.Fatalf("want a missing file, got exists=%v err=%v", exists, err)
+	}
+
+	if err := fs.WriteFile(path, "packs/books:\n"); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	text, exists, err := fs.ReadFile(path)
+	if err != nil || !exists || text != "packs/books:\n" {
+		t.Errorf("unexpected content %q (exists=%v err=%v)", text, exists, err)
+	}
+
+	if err := fs.RemoveFile(path); err != nil {
+		t.Fatalf("failed to remove file: %v", err)
+	}
+	if err := fs.RemoveFile(path); err != nil {
+		t.Errorf("removing a missing file should succeed, got %v", err)
+	}
+}
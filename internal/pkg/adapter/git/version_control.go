@@ -0,0 +1,99 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/port/out"
+)
+
+// hunkHeaderRegex matches the lines of the new file in a hunk header, e.g. "@@ -10,2 +12,3 @@".
+var hunkHeaderRegex = regexp.MustCompile(`^@@ -\d+(?:,\d+)? \+(\d+)(?:,(\d+))? @@`)
+
+// VersionControl reads the changes of the working tree with the git command.
+type VersionControl struct{}
+
+func NewVersionControl() *VersionControl {
+	return &VersionControl{}
+}
+
+func (g *VersionControl) ChangedLines(context context.Context, rootPath string, base string) (*domain.ChangedLines, error) {
+	// On a branch, only the changes since it forked from the base are its own
+	if mergeBase, err := g.run(context, rootPath, "merge-base", base, "HEAD"); err == nil {
+		base = strings.TrimSpace(mergeBase)
+	}
+
+	// --relative keeps the changes under the root, with paths relative to it
+	diff, err := g.run(context, rootPath, "diff", "--unified=0", "--no-color", "--no-ext-diff", "--relative",
+		"--src-prefix=a/", "--dst-prefix=b/", base, "--")
+	if err != nil {
+		return nil, err
+	}
+	changes := parseDiff(diff)
+
+	untracked, err := g.run(context, rootPath, "ls-files", "--others", "--exclude-standard")
+	if err != nil {
+		return nil, err
+	}
+	for _, filePath := range strings.Split(untracked, "\n") {
+		if filePath != "" {
+			changes.AddFile(filePath)
+		}
+	}
+	return changes, nil
+}
+
+func (g *VersionControl) run(context context.Context, rootPath string, args ...string) (string, error) {
+	// Paths with special characters are printed as they are instead of quoted
+	cmd := exec.CommandContext(context, "git", append([]string{"-c", "core.quotePath=false"}, args...)...)
+	cmd.Dir = rootPath
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return "", fmt.Errorf("git %s: %s", args[0], message)
+		}
+		return "", fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return string(output), nil
+}
+
+// parseDiff collects the added lines of a diff without context lines.
+// Deleted lines leave nothing to report on, so they are skipped.
+func parseDiff(diff string) *domain.ChangedLines {
+	changes := domain.NewChangedLines()
+	filePath := ""
+	for _, line := range strings.Split(diff, "\n") {
+		switch {
+		case strings.HasPrefix(line, "+++ "):
+			// "+++ /dev/null" for a deleted file. Git ends names with spaces with a tab
+			filePath = ""
+			if name, ok := strings.CutPrefix(line, "+++ b/"); ok {
+				filePath = strings.TrimSuffix(name, "\t")
+			}
+		case strings.HasPrefix(line, "@@ ") && filePath != "":
+			match := hunkHeaderRegex.FindStringSubmatch(line)
+			if match == nil {
+				continue
+			}
+			start, _ := strconv.ParseUint(match[1], 10, 32)
+			count := uint64(1)
+			if match[2] != "" {
+				count, _ = strconv.ParseUint(match[2], 10, 32)
+			}
+			if count > 0 {
+				changes.Add(filePath, uint32(start), uint32(start+count-1))
+			}
+		}
+	}
+	return changes
+}
+
+var _ out.VersionControl = (*VersionControl)(nil)
@@ -0,0 +1,95 @@
+package git
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseDiff(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "diff.txt"))
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+	changes := parseDiff(string(data))
+
+	wantFiles := []string{"packs/books/app/models/book.rb", "packs/users/app/models/user.rb"}
+	if got := changes.Files(); !reflect.DeepEqual(got, wantFiles) {
+		t.Errorf("want files %v, got %v", wantFiles, got)
+	}
+	tests := []struct {
+		file string
+		line uint32
+		want bool
+	}{
+		{"packs/users/app/models/user.rb", 2, false},
+		{"packs/users/app/models/user.rb", 3, true},
+		{"packs/users/app/models/user.rb", 4, true},
+		{"packs/users/app/models/user.rb", 12, true},
+		{"packs/users/app/models/user.rb", 21, false},
+		{"packs/books/app/models/book.rb", 1, true},
+		{"packs/books/app/models/book.rb", 2, true},
+	}
+	for _, tt := range tests {
+		if got := changes.Contains(tt.file, tt.line); got != tt.want {
+			t.Errorf("%s:%d: want %v, got %v", tt.file, tt.line, tt.want, got)
+		}
+	}
+}
+
+func TestVersionControl_ChangedLines(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	repository := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		cmd.Dir = repository
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, output)
+		}
+	}
+	write := func(path, text string) {
+		t.Helper()
+		path = filepath.Join(repository, path)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(text), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	git("init", "--quiet", "--initial-branch=main")
+	write("project/app/models/user.rb", "class User\n  def name\n  end\nend\n")
+	git("add", "-A")
+	git("commit", "--quiet", "-m", "initial")
+	git("checkout", "--quiet", "-b", "feature")
+	write("project/app/models/user.rb", "class User\n  def name\n    Book.first\n  end\nend\n")
+	git("commit", "--quiet", "-am", "reference a book")
+	write("project/app/models/book.rb", "class Book\nend\n")
+	write("outside.rb", "Book\n")
+
+	changes, err := NewVersionControl().ChangedLines(context.Background(), filepath.Join(repository, "project"), "main")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	wantFiles := []string{"app/models/book.rb", "app/models/user.rb"}
+	if got := changes.Files(); !reflect.DeepEqual(got, wantFiles) {
+		t.Errorf("want files %v relative to the root, got %v", wantFiles, got)
+	}
+	if !changes.Contains("app/models/user.rb", 3) || changes.Contains("app/models/user.rb", 2) {
+		t.Errorf("want only the committed line changed, got %+v", changes)
+	}
+	if !changes.Contains("app/models/book.rb", 1) {
+		t.Errorf("want the untracked file changed, got %+v", changes)
+	}
+
+	if _, err := NewVersionControl().ChangedLines(context.Background(), repository, "unknown-ref"); err == nil {
+		t.Error("want an error for an unknown base")
+	}
+}
@@ -0,0 +1,56 @@
+package inmemory
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/port/out"
+)
+
+type DocumentRepository struct {
+	mu        sync.RWMutex
+	documents map[string]*domain.Document
+}
+
+func NewDocumentRepository() *DocumentRepository {
+	return &DocumentRepository{documents: make(map[string]*domain.Document)}
+}
+
+func (r *DocumentRepository) Save(document *domain.Document) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.documents[document.URI] = document
+	return nil
+}
+
+func (r *DocumentRepository) GetDocument(uri string) (*domain.Document, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	document, ok := r.documents[uri]
+	if !ok {
+		return nil, fmt.Errorf("document not found: %s", uri)
+	}
+	return document, nil
+}
+
+func (r *DocumentRepository) GetDocuments() ([]*domain.Document, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	documents := make([]*domain.Document, 0, len(r.documents))
+	for _, uri := range slices.Sorted(maps.Keys(r.documents)) {
+		documents = append(documents, r.documents[uri])
+	}
+	return documents, nil
+}
+
+func (r *DocumentRepository) Delete(uri string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.documents, uri)
+	return nil
+}
+
+var _ out.DocumentRepository = (*DocumentRepository)(nil)
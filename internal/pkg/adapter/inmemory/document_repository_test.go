@@ -0,0 +1,52 @@
+package inmemory
+
+import (
+	"testing"
+
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+)
+
+func TestDocumentRepository(t *testing.T) {
+	repo := NewDocumentRepository()
+	uri := "file:///root/packs/books/package.yml"
+
+	if _, err := repo.GetDocument(uri); err == nil {
+		t.Error("expected error for unknown document")
+	}
+
+	if err := repo.Save(domain.NewDocument(uri, "enforce_dependencies: true\n")); err != nil {
+		t.Fatalf("failed to save document: %v", err)
+	}
+	if err := repo.Save(domain.NewDocument(uri, "enforce_dependencies: strict\n")); err != nil {
+		t.Fatalf("failed to save document: %v", err)
+	}
+
+	got, err := repo.GetDocument(uri)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Text != "enforce_dependencies: strict\n" {
+		t.Errorf("expected latest text, got %q", got.Text)
+	}
+
+	if err := repo.Delete(uri); err != nil {
+		t.Fatalf("failed to delete document: %v", err)
+	}
+	if _, err := repo.GetDocument(uri); err == nil {
+		t.Error("expected error after delete")
+	}
+}
+
+func TestDocumentRepository_GetDocuments(t *testing.T) {
+	repo := NewDocumentRepository()
+	_ = repo.Save(domain.NewDocument("file:///root/packs/users/package.yml", ""))
+	_ = repo.Save(domain.NewDocument("file:///root/packs/books/package.yml", ""))
+
+	got, err := repo.GetDocuments()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 2 || got[0].URI != "file:///root/packs/books/package.yml" || got[1].URI != "file:///root/packs/users/package.yml" {
+		t.Errorf("unexpected documents: %+v", got)
+	}
+}
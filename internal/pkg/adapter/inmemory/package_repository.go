@@ -0,0 +1,36 @@
+package inmemory
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/port/out"
+)
+
+type PackageRepository struct {
+	mu         sync.RWMutex
+	packageSet *domain.PackageSet
+}
+
+func NewPackageRepository() *PackageRepository {
+	return &PackageRepository{}
+}
+
+func (r *PackageRepository) Save(packageSet *domain.PackageSet) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.packageSet = packageSet
+	return nil
+}
+
+func (r *PackageRepository) GetPackageSet() (*domain.PackageSet, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.packageSet == nil {
+		return nil, errors.New("packages not loaded")
+	}
+	return r.packageSet, nil
+}
+
+var _ out.PackageRepository = (*PackageRepository)(nil)
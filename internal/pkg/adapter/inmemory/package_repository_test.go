@@ -0,0 +1,35 @@
+package inmemory
+
+import (
+	"testing"
+
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+)
+
+func TestPackageRepository(t *testing.T) {
+	t.Run("SaveAndGet", func(t *testing.T) {
+		repo := NewPackageRepository()
+		set := domain.NewPackageSet(domain.NewPackwerkConfig(), []*domain.Package{domain.NewPackage("packs/books")})
+		if err := repo.Save(set); err != nil {
+			t.Fatalf("failed to save package set: %v", err)
+		}
+		got, err := repo.GetPackageSet()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, ok := got.Get("packs/books"); !ok {
+			t.Error("expected packs/books in package set")
+		}
+	})
+
+	t.Run("GetPackageSet_NotLoaded", func(t *testing.T) {
+		repo := NewPackageRepository()
+		got, err := repo.GetPackageSet()
+		if err == nil {
+			t.Error("expected error when packages are not loaded")
+		}
+		if got != nil {
+			t.Error("expected nil package set when not loaded")
+		}
+	})
+}
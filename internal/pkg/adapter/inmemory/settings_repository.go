@@ -0,0 +1,35 @@
+package inmemory
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/port/out"
+)
+
+type SettingsRepository struct {
+	mu       sync.RWMutex
+	settings domain.Settings
+}
+
+func NewSettingsRepository() *SettingsRepository {
+	return &SettingsRepository{settings: domain.NewSettings()}
+}
+
+func (r *SettingsRepository) Save(settings domain.Settings) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	settings.CheckerCommand = slices.Clone(settings.CheckerCommand)
+	settings.ExcludePaths = slices.Clone(settings.ExcludePaths)
+	r.settings = settings
+	return nil
+}
+
+func (r *SettingsRepository) GetSettings() (domain.Settings, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.settings, nil
+}
+
+var _ out.SettingsRepository = (*SettingsRepository)(nil)
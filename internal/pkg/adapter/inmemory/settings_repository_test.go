@@ -0,0 +1,28 @@
+package inmemory
+
+import (
+	"testing"
+
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+)
+
+func TestSettingsRepository(t *testing.T) {
+	repo := NewSettingsRepository()
+
+	got, _ := repo.GetSettings()
+	if got.Severity != domain.SeverityError || got.Concurrency != 1 {
+		t.Errorf("expected the default settings, got %+v", got)
+	}
+
+	excludePaths := []string{"spec/**"}
+	_ = repo.Save(domain.Settings{Severity: domain.SeverityWarning, ExcludePaths: excludePaths, Concurrency: 2})
+	excludePaths[0] = "changed"
+
+	got, _ = repo.GetSettings()
+	if got.Severity != domain.SeverityWarning || got.Concurrency != 2 {
+		t.Errorf("expected the saved settings, got %+v", got)
+	}
+	if got.ExcludePaths[0] != "spec/**" {
+		t.Errorf("expected the saved settings to be a copy, got %v", got.ExcludePaths)
+	}
+}
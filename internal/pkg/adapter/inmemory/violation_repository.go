@@ -0,0 +1,62 @@
+package inmemory
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/port/out"
+)
+
+type ViolationRepository struct {
+	mu         sync.RWMutex
+	violations []domain.Violation
+	fixed      []string // the files left without violations by the latest ReplaceAll
+}
+
+func NewViolationRepository() *ViolationRepository {
+	return &ViolationRepository{}
+}
+
+func (r *ViolationRepository) ReplaceAll(violations []domain.Violation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	files := make(map[string]struct{}, len(violations))
+	for _, v := range violations {
+		files[v.File] = struct{}{}
+	}
+	// The files of the previous check missing from this one were fixed
+	r.fixed = nil
+	for _, v := range r.violations {
+		if _, ok := files[v.File]; !ok {
+			files[v.File] = struct{}{}
+			r.fixed = append(r.fixed, v.File)
+		}
+	}
+	r.violations = slices.Clone(violations)
+	return nil
+}
+
+func (r *ViolationRepository) ReplaceFiles(files []string, violations []domain.Violation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.violations = slices.DeleteFunc(r.violations, func(v domain.Violation) bool {
+		return slices.Contains(files, v.File)
+	})
+	r.violations = append(r.violations, violations...)
+	return nil
+}
+
+func (r *ViolationRepository) GetViolations() ([]domain.Violation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return slices.Clone(r.violations), nil
+}
+
+func (r *ViolationRepository) GetFixedFiles() ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return slices.Clone(r.fixed), nil
+}
+
+var _ out.ViolationRepository = (*ViolationRepository)(nil)
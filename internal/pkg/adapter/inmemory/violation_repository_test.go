@@ -0,0 +1,54 @@
+package inmemory
+
+import (
+	"testing"
+
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+)
+
+func TestViolationRepository(t *testing.T) {
+	t.Run("ReplaceAll", func(t *testing.T) {
+		repo := NewViolationRepository()
+		_ = repo.ReplaceAll([]domain.Violation{{File: "a.rb"}, {File: "b.rb"}})
+		_ = repo.ReplaceAll([]domain.Violation{{File: "c.rb"}})
+
+		got, _ := repo.GetViolations()
+		if len(got) != 1 || got[0].File != "c.rb" {
+			t.Errorf("unexpected violations: %+v", got)
+		}
+	})
+
+	t.Run("GetFixedFiles", func(t *testing.T) {
+		repo := NewViolationRepository()
+		_ = repo.ReplaceAll([]domain.Violation{{File: "a.rb", Line: 1}, {File: "a.rb", Line: 2}, {File: "b.rb"}})
+		_ = repo.ReplaceAll([]domain.Violation{{File: "b.rb"}, {File: "c.rb"}})
+
+		got, _ := repo.GetFixedFiles()
+		if len(got) != 1 || got[0] != "a.rb" {
+			t.Errorf("want a.rb fixed, got %+v", got)
+		}
+
+		_ = repo.ReplaceAll([]domain.Violation{{File: "b.rb"}, {File: "c.rb"}})
+		if got, _ := repo.GetFixedFiles(); len(got) != 0 {
+			t.Errorf("want nothing fixed since the previous check, got %+v", got)
+		}
+	})
+
+	t.Run("ReplaceFiles", func(t *testing.T) {
+		repo := NewViolationRepository()
+		_ = repo.ReplaceAll([]domain.Violation{{File: "a.rb", Line: 1}, {File: "a.rb", Line: 2}, {File: "b.rb", Line: 1}})
+		_ = repo.ReplaceFiles([]string{"a.rb", "c.rb"}, []domain.Violation{{File: "c.rb", Line: 3}})
+
+		got, _ := repo.GetViolations()
+		if len(got) != 2 || got[0].File != "b.rb" || got[1].File != "c.rb" {
+			t.Errorf("unexpected violations: %+v", got)
+		}
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		got, err := NewViolationRepository().GetViolations()
+		if err != nil || len(got) != 0 {
+			t.Errorf("want no violations, got %+v (%v)", got, err)
+		}
+	})
+}
@@ -1,23 +1,243 @@
 package lsp
 
 import (
+	"fmt"
+
 	"github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
 	protocol "github.com/tliron/glsp/protocol_3_16"
 )
 
+func MapRange(r domain.Range) protocol.Range {
+	return protocol.Range{
+		Start: protocol.Position{Line: r.Start.Line, Character: r.Start.Character},
+		End:   protocol.Position{Line: r.End.Line, Character: r.End.Character},
+	}
+}
+
+func MapLocations(locations []domain.Location) []protocol.Location {
+	lspLocations := make([]protocol.Location, 0, len(locations))
+	for _, l := range locations {
+		lspLocations = append(lspLocations, protocol.Location{
+			URI:   protocol.DocumentUri(l.URI),
+			Range: MapRange(l.Range),
+		})
+	}
+	return lspLocations
+}
+
 func MapDiagnostics(diags []domain.Diagnostic) []protocol.Diagnostic {
 	lspDiagnostics := make([]protocol.Diagnostic, 0, len(diags))
 	for _, d := range diags {
 		severity := protocol.DiagnosticSeverity(d.Severity)
-		lspDiagnostics = append(lspDiagnostics, protocol.Diagnostic{
-			Range: protocol.Range{
-				Start: protocol.Position{Line: d.Range.Start.Line, Character: d.Range.Start.Character},
-				End:   protocol.Position{Line: d.Range.End.Line, Character: d.Range.End.Character},
-			},
+		lspDiagnostic := protocol.Diagnostic{
+			Range:    MapRange(d.Range),
 			Severity: &severity,
 			Source:   &d.Source,
 			Message:  d.Message,
-		})
+		}
+		if d.Code != "" {
+			lspDiagnostic.Code = &protocol.IntegerOrString{Value: d.Code}
+		}
+		if d.CodeHref != "" {
+			lspDiagnostic.CodeDescription = &protocol.CodeDescription{HRef: protocol.URI(d.CodeHref)}
+		}
+		if d.Violation != nil {
+			lspDiagnostic.Data = MapViolationData(d.Violation)
+		}
+		for _, info := range d.RelatedInformation {
+			lspDiagnostic.RelatedInformation = append(lspDiagnostic.RelatedInformation, protocol.DiagnosticRelatedInformation{
+				Location: protocol.Location{URI: protocol.DocumentUri(info.Location.URI), Range: MapRange(info.Location.Range)},
+				Message:  info.Message,
+			})
+		}
+		lspDiagnostics = append(lspDiagnostics, lspDiagnostic)
 	}
 	return lspDiagnostics
 }
+
+func MapViolationData(v *domain.Violation) ViolationData {
+	return ViolationData{
+		File:               v.File,
+		Line:               v.Line,
+		Character:          v.Character,
+		Type:               v.Kind(),
+		Constant:           v.Constant,
+		ReferencedPackage:  v.ReferencedPackage,
+		ReferencingPackage: v.ReferencingPackage,
+	}
+}
+
+func MapPackageInfo(pkg *domain.Package) PackageInfo {
+	return PackageInfo{
+		Name:   pkg.Name,
+		Layer:  pkg.Layer,
+		Owner:  pkg.Owner,
+		IsRoot: pkg.IsRoot(),
+	}
+}
+
+func MapTextEdit(edit domain.TextEdit) protocol.TextEdit {
+	return protocol.TextEdit{Range: MapRange(edit.Range), NewText: edit.NewText}
+}
+
+func MapCompletionItems(items []domain.CompletionItem) []protocol.CompletionItem {
+	lspItems := make([]protocol.CompletionItem, 0, len(items))
+	for i, item := range items {
+		kind := mapCompletionItemKind(item.Kind)
+		// Keep the order decided by the usecase
+		sortText := fmt.Sprintf("%04d", i)
+		lspItem := protocol.CompletionItem{
+			Label:    item.Label,
+			Kind:     &kind,
+			SortText: &sortText,
+			TextEdit: MapTextEdit(item.Edit),
+		}
+		if item.Detail != "" {
+			detail := item.Detail
+			lspItem.Detail = &detail
+		}
+		lspItems = append(lspItems, lspItem)
+	}
+	return lspItems
+}
+
+func mapCompletionItemKind(kind domain.CompletionItemKind) protocol.CompletionItemKind {
+	switch kind {
+	case domain.CompletionKindPackage:
+		return protocol.CompletionItemKindModule
+	case domain.CompletionKindKey:
+		return protocol.CompletionItemKindProperty
+	default:
+		return protocol.CompletionItemKindValue
+	}
+}
+
+func MapSymbols(symbols []domain.Symbol) []protocol.SymbolInformation {
+	lspSymbols := make([]protocol.SymbolInformation, 0, len(symbols))
+	for _, symbol := range symbols {
+		lspSymbol := protocol.SymbolInformation{
+			Name: symbol.Name,
+			Kind: mapSymbolKind(symbol.Kind),
+			Location: protocol.Location{
+				URI:   protocol.DocumentUri(symbol.Location.URI),
+				Range: MapRange(symbol.Location.Range),
+			},
+		}
+		if symbol.ContainerName != "" {
+			containerName := symbol.ContainerName
+			lspSymbol.ContainerName = &containerName
+		}
+		lspSymbols = append(lspSymbols, lspSymbol)
+	}
+	return lspSymbols
+}
+
+func MapDocumentSymbols(symbols []domain.DocumentSymbol) []protocol.DocumentSymbol {
+	lspSymbols := make([]protocol.DocumentSymbol, 0, len(symbols))
+	for _, symbol := range symbols {
+		lspSymbol := protocol.DocumentSymbol{
+			Name:           symbol.Name,
+			Kind:           mapSymbolKind(symbol.Kind),
+			Range:          MapRange(symbol.Range),
+			SelectionRange: MapRange(symbol.SelectionRange),
+		}
+		if symbol.Detail != "" {
+			detail := symbol.Detail
+			lspSymbol.Detail = &detail
+		}
+		if len(symbol.Children) > 0 {
+			lspSymbol.Children = MapDocumentSymbols(symbol.Children)
+		}
+		lspSymbols = append(lspSymbols, lspSymbol)
+	}
+	return lspSymbols
+}
+
+func MapCodeLenses(uri string, codeLenses []domain.CodeLens) []protocol.CodeLens {
+	lspCodeLenses := make([]protocol.CodeLens, 0, len(codeLenses))
+	for _, codeLens := range codeLenses {
+		lspRange := MapRange(codeLens.Range)
+		lspCodeLenses = append(lspCodeLenses, protocol.CodeLens{
+			Range: lspRange,
+			Command: &protocol.Command{
+				Title:     codeLens.Title,
+				Command:   CommandShowLocations,
+				Arguments: []any{uri, lspRange.Start, MapLocations(codeLens.Locations)},
+			},
+		})
+	}
+	return lspCodeLenses
+}
+
+func MapHover(hover *domain.Hover) *protocol.Hover {
+	if hover == nil {
+		return nil
+	}
+	lspRange := MapRange(hover.Range)
+	return &protocol.Hover{
+		Contents: protocol.MarkupContent{Kind: protocol.MarkupKindMarkdown, Value: hover.Contents},
+		Range:    &lspRange,
+	}
+}
+
+// changeAnnotationID marks the changes of a WorkspaceEdit as needing confirmation,
+// which makes clients show a preview before applying them
+const changeAnnotationID = "wpks"
+
+func MapWorkspaceEdit(edit *domain.WorkspaceEdit) protocol.WorkspaceEdit {
+	annotationID := changeAnnotationID
+	needsConfirmation := true
+	documentChanges := make([]any, 0, len(edit.Changes))
+	for _, change := range edit.Changes {
+		uri := protocol.DocumentUri(change.URI)
+		switch change.Kind {
+		case domain.FileChangeCreate:
+			documentChanges = append(documentChanges,
+				protocol.CreateFile{Kind: "create", URI: uri, AnnotationID: &annotationID},
+				mapWholeTextEdit(uri, "", change.After, annotationID),
+			)
+		case domain.FileChangeDelete:
+			documentChanges = append(documentChanges, protocol.DeleteFile{Kind: "delete", URI: uri, AnnotationID: &annotationID})
+		default:
+			documentChanges = append(documentChanges, mapWholeTextEdit(uri, change.Before, change.After, annotationID))
+		}
+	}
+	return protocol.WorkspaceEdit{
+		DocumentChanges: documentChanges,
+		ChangeAnnotations: map[protocol.ChangeAnnotationIdentifier]protocol.ChangeAnnotation{
+			annotationID: {Label: edit.Label, NeedsConfirmation: &needsConfirmation},
+		},
+	}
+}
+
+// mapWholeTextEdit replaces the whole text of a file
+func mapWholeTextEdit(uri protocol.DocumentUri, before string, after string, annotationID string) protocol.TextDocumentEdit {
+	return protocol.TextDocumentEdit{
+		TextDocument: protocol.OptionalVersionedTextDocumentIdentifier{
+			TextDocumentIdentifier: protocol.TextDocumentIdentifier{URI: uri},
+		},
+		Edits: []any{
+			protocol.AnnotatedTextEdit{
+				TextEdit:     protocol.TextEdit{Range: MapRange(domain.WholeRange(before)), NewText: after},
+				AnnotationID: annotationID,
+			},
+		},
+	}
+}
+
+func mapSymbolKind(kind domain.SymbolKind) protocol.SymbolKind {
+	switch kind {
+	case domain.SymbolKindPackage:
+		return protocol.SymbolKindPackage
+	case domain.SymbolKindNamespace:
+		return protocol.SymbolKindNamespace
+	case domain.SymbolKindKey:
+		return protocol.SymbolKindKey
+	case domain.SymbolKindArray:
+		return protocol.SymbolKindArray
+	case domain.SymbolKindFile:
+		return protocol.SymbolKindFile
+	default:
+		return protocol.SymbolKindConstant
+	}
+}
@@ -87,6 +87,68 @@ func TestMapDiagnostics(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "violation diagnostic",
+			input: []domain.Diagnostic{
+				{
+					Severity: domain.SeverityError,
+					Source:   "packwerk",
+					Code:     "privacy",
+					CodeHref: "https://github.com/Shopify/packwerk/blob/main/TROUBLESHOOT.md#Troubleshooting-violations",
+					Message:  "Privacy violation",
+					Violation: &domain.Violation{
+						File:               "packs/users/app/models/user.rb",
+						Line:               8,
+						Character:          4,
+						Type:               "Privacy violation",
+						Constant:           "::Books::Inventory",
+						ReferencedPackage:  "packs/books",
+						ReferencingPackage: "packs/users",
+					},
+				},
+			},
+			want: []protocol.Diagnostic{
+				{
+					Severity:        Ptr(protocol.DiagnosticSeverity(domain.SeverityError)),
+					Source:          Ptr("packwerk"),
+					Code:            &protocol.IntegerOrString{Value: "privacy"},
+					CodeDescription: &protocol.CodeDescription{HRef: "https://github.com/Shopify/packwerk/blob/main/TROUBLESHOOT.md#Troubleshooting-violations"},
+					Message:         "Privacy violation",
+					Data: ViolationData{
+						File:               "packs/users/app/models/user.rb",
+						Line:               8,
+						Character:          4,
+						Type:               "privacy",
+						Constant:           "::Books::Inventory",
+						ReferencedPackage:  "packs/books",
+						ReferencingPackage: "packs/users",
+					},
+				},
+			},
+		},
+		{
+			name: "diagnostic with related information",
+			input: []domain.Diagnostic{
+				{
+					Severity: domain.SeverityError,
+					Source:   "packwerk",
+					Message:  "cycle",
+					RelatedInformation: []domain.DiagnosticRelatedInformation{
+						{Location: domain.Location{URI: "file:///root/packs/books/package.yml"}, Message: "packs/books depends on packs/users"},
+					},
+				},
+			},
+			want: []protocol.Diagnostic{
+				{
+					Severity: Ptr(protocol.DiagnosticSeverity(domain.SeverityError)),
+					Source:   Ptr("packwerk"),
+					Message:  "cycle",
+					RelatedInformation: []protocol.DiagnosticRelatedInformation{
+						{Location: protocol.Location{URI: "file:///root/packs/books/package.yml"}, Message: "packs/books depends on packs/users"},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -99,6 +161,229 @@ func TestMapDiagnostics(t *testing.T) {
 	}
 }
 
+func TestMapLocations(t *testing.T) {
+	input := []domain.Location{
+		{
+			URI: "file:///root/packs/books/package.yml",
+			Range: domain.Range{
+				Start: domain.Position{Line: 0, Character: 0},
+				End:   domain.Position{Line: 0, Character: 0},
+			},
+		},
+	}
+	want := []protocol.Location{
+		{
+			URI: "file:///root/packs/books/package.yml",
+			Range: protocol.Range{
+				Start: protocol.Position{Line: 0, Character: 0},
+				End:   protocol.Position{Line: 0, Character: 0},
+			},
+		},
+	}
+	if got := MapLocations(input); !reflect.DeepEqual(got, want) {
+		t.Errorf("want %+v, got %+v", want, got)
+	}
+	if got := MapLocations(nil); got == nil || len(got) != 0 {
+		t.Errorf("expected empty slice, got %+v", got)
+	}
+}
+
+func TestMapCompletionItems(t *testing.T) {
+	editRange := domain.Range{
+		Start: domain.Position{Line: 1, Character: 4},
+		End:   domain.Position{Line: 1, Character: 6},
+	}
+	input := []domain.CompletionItem{
+		{Label: "packs/books", Kind: domain.CompletionKindPackage, Detail: "packs/books/package.yml", Edit: domain.TextEdit{Range: editRange, NewText: "packs/books"}},
+		{Label: "true", Kind: domain.CompletionKindValue, Edit: domain.TextEdit{Range: editRange, NewText: "true"}},
+	}
+	lspRange := protocol.Range{
+		Start: protocol.Position{Line: 1, Character: 4},
+		End:   protocol.Position{Line: 1, Character: 6},
+	}
+	want := []protocol.CompletionItem{
+		{
+			Label:    "packs/books",
+			Kind:     Ptr(protocol.CompletionItemKindModule),
+			Detail:   Ptr("packs/books/package.yml"),
+			SortText: Ptr("0000"),
+			TextEdit: protocol.TextEdit{Range: lspRange, NewText: "packs/books"},
+		},
+		{
+			Label:    "true",
+			Kind:     Ptr(protocol.CompletionItemKindValue),
+			SortText: Ptr("0001"),
+			TextEdit: protocol.TextEdit{Range: lspRange, NewText: "true"},
+		},
+	}
+	if got := MapCompletionItems(input); !reflect.DeepEqual(got, want) {
+		t.Errorf("want %+v, got %+v", want, got)
+	}
+}
+
+func TestMapSymbols(t *testing.T) {
+	input := []domain.Symbol{
+		{Name: "packs/books", Kind: domain.SymbolKindPackage, Location: domain.Location{URI: "file:///root/packs/books/package.yml"}},
+		{Name: "Books::Api", Kind: domain.SymbolKindConstant, Location: domain.Location{URI: "file:///root/packs/books/app/public/books/api.rb"}, ContainerName: "packs/books"},
+	}
+	want := []protocol.SymbolInformation{
+		{Name: "packs/books", Kind: protocol.SymbolKindPackage, Location: protocol.Location{URI: "file:///root/packs/books/package.yml"}},
+		{Name: "Books::Api", Kind: protocol.SymbolKindConstant, Location: protocol.Location{URI: "file:///root/packs/books/app/public/books/api.rb"}, ContainerName: Ptr("packs/books")},
+	}
+	if got := MapSymbols(input); !reflect.DeepEqual(got, want) {
+		t.Errorf("want %+v, got %+v", want, got)
+	}
+}
+
+func TestMapDocumentSymbols(t *testing.T) {
+	fileRange := domain.Range{Start: domain.Position{Line: 4, Character: 6}, End: domain.Position{Line: 4, Character: 36}}
+	input := []domain.DocumentSymbol{
+		{
+			Name:   "packs/books",
+			Detail: "1 constant",
+			Kind:   domain.SymbolKindNamespace,
+			Children: []domain.DocumentSymbol{
+				{
+					Name:     "::Book",
+					Detail:   "privacy · 1 file",
+					Kind:     domain.SymbolKindConstant,
+					Children: []domain.DocumentSymbol{{Name: "packs/users/app/models/user.rb", Kind: domain.SymbolKindFile, Range: fileRange, SelectionRange: fileRange}},
+				},
+			},
+		},
+	}
+	lspFileRange := protocol.Range{Start: protocol.Position{Line: 4, Character: 6}, End: protocol.Position{Line: 4, Character: 36}}
+	want := []protocol.DocumentSymbol{
+		{
+			Name:   "packs/books",
+			Detail: Ptr("1 constant"),
+			Kind:   protocol.SymbolKindNamespace,
+			Children: []protocol.DocumentSymbol{
+				{
+					Name:     "::Book",
+					Detail:   Ptr("privacy · 1 file"),
+					Kind:     protocol.SymbolKindConstant,
+					Children: []protocol.DocumentSymbol{{Name: "packs/users/app/models/user.rb", Kind: protocol.SymbolKindFile, Range: lspFileRange, SelectionRange: lspFileRange}},
+				},
+			},
+		},
+	}
+	if got := MapDocumentSymbols(input); !reflect.DeepEqual(got, want) {
+		t.Errorf("want %+v, got %+v", want, got)
+	}
+}
+
+func TestMapCodeLenses(t *testing.T) {
+	violationRange := domain.Range{Start: domain.Position{Line: 19, Character: 4}, End: domain.Position{Line: 19, Character: 5}}
+	input := []domain.CodeLens{
+		{
+			Title:     "1 dependency violation · 0 todo entries",
+			Locations: []domain.Location{{URI: "file:///root/packs/users/app/models/user.rb", Range: violationRange}},
+		},
+	}
+	want := []protocol.CodeLens{
+		{
+			Command: &protocol.Command{
+				Title:   "1 dependency violation · 0 todo entries",
+				Command: CommandShowLocations,
+				Arguments: []any{
+					"file:///root/packs/users/package.yml",
+					protocol.Position{Line: 0, Character: 0},
+					[]protocol.Location{{URI: "file:///root/packs/users/app/models/user.rb", Range: MapRange(violationRange)}},
+				},
+			},
+		},
+	}
+	if got := MapCodeLenses("file:///root/packs/users/package.yml", input); !reflect.DeepEqual(got, want) {
+		t.Errorf("want %+v, got %+v", want, got)
+	}
+}
+
+func TestMapHover(t *testing.T) {
+	if got := MapHover(nil); got != nil {
+		t.Errorf("want nil, got %+v", got)
+	}
+
+	input := &domain.Hover{
+		Contents: "**Privacy violation**",
+		Range:    domain.Range{Start: domain.Position{Line: 2, Character: 4}, End: domain.Position{Line: 2, Character: 20}},
+	}
+	want := &protocol.Hover{
+		Contents: protocol.MarkupContent{Kind: protocol.MarkupKindMarkdown, Value: "**Privacy violation**"},
+		Range:    &protocol.Range{Start: protocol.Position{Line: 2, Character: 4}, End: protocol.Position{Line: 2, Character: 20}},
+	}
+	if got := MapHover(input); !reflect.DeepEqual(got, want) {
+		t.Errorf("want %+v, got %+v", want, got)
+	}
+}
+
+func TestMapWorkspaceEdit(t *testing.T) {
+	input := &domain.WorkspaceEdit{
+		Label: "Update package_todo.yml",
+		Changes: []domain.FileChange{
+			{URI: "file:///root/packs/users/package_todo.yml", Kind: domain.FileChangeModify, Before: "a\nb\n", After: "c\n"},
+			{URI: "file:///root/packs/orders/package_todo.yml", Kind: domain.FileChangeCreate, After: "d\n"},
+			{URI: "file:///root/packs/books/package_todo.yml", Kind: domain.FileChangeDelete, Before: "e\n"},
+		},
+	}
+	annotationID := "wpks"
+	wholeEdit := func(uri string, end protocol.Position, newText string) protocol.TextDocumentEdit {
+		return protocol.TextDocumentEdit{
+			TextDocument: protocol.OptionalVersionedTextDocumentIdentifier{
+				TextDocumentIdentifier: protocol.TextDocumentIdentifier{URI: protocol.DocumentUri(uri)},
+			},
+			Edits: []any{
+				protocol.AnnotatedTextEdit{
+					TextEdit:     protocol.TextEdit{Range: protocol.Range{End: end}, NewText: newText},
+					AnnotationID: annotationID,
+				},
+			},
+		}
+	}
+	want := protocol.WorkspaceEdit{
+		DocumentChanges: []any{
+			wholeEdit("file:///root/packs/users/package_todo.yml", protocol.Position{Line: 2, Character: 0}, "c\n"),
+			protocol.CreateFile{Kind: "create", URI: "file:///root/packs/orders/package_todo.yml", AnnotationID: &annotationID},
+			wholeEdit("file:///root/packs/orders/package_todo.yml", protocol.Position{}, "d\n"),
+			protocol.DeleteFile{Kind: "delete", URI: "file:///root/packs/books/package_todo.yml", AnnotationID: &annotationID},
+		},
+		ChangeAnnotations: map[protocol.ChangeAnnotationIdentifier]protocol.ChangeAnnotation{
+			"wpks": {Label: "Update package_todo.yml", NeedsConfirmation: Ptr(true)},
+		},
+	}
+	if got := MapWorkspaceEdit(input); !reflect.DeepEqual(got, want) {
+		t.Errorf("want %+v, got %+v", want, got)
+	}
+}
+
+func TestMapPackageInfo(t *testing.T) {
+	tests := []struct {
+		name  string
+		input *domain.Package
+		want  PackageInfo
+	}{
+		{
+			name:  "root pack",
+			input: domain.NewPackage("."),
+			want:  PackageInfo{Name: ".", IsRoot: true},
+		},
+		{
+			name:  "pack with metadata",
+			input: &domain.Package{Name: "packs/books", Layer: "utility", Owner: "Library"},
+			want:  PackageInfo{Name: "packs/books", Layer: "utility", Owner: "Library"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := MapPackageInfo(tt.input)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("want %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
 func Ptr[T any](v T) *T {
 	return &v
 }
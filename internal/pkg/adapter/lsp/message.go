@@ -8,11 +8,73 @@ import (
 	protocol "github.com/tliron/glsp/protocol_3_16"
 )
 
+// Commands the server executes through workspace/executeCommand
+const (
+	CommandCheckAll        = "wpks.checkAll"
+	CommandCheckFile       = "wpks.checkFile"
+	CommandUpdateTodo      = "wpks.updateTodo"
+	CommandValidate        = "wpks.validate"
+	CommandClearCache      = "wpks.clearCache"
+	CommandRestartChecker  = "wpks.restartChecker"
+	CommandCreateBaseline  = "wpks.createBaseline"
+	CommandRefreshBaseline = "wpks.refreshBaseline"
+)
+
+// Commands lists the commands advertised in the executeCommandProvider capability
+var Commands = []string{
+	CommandCheckAll,
+	CommandCheckFile,
+	CommandUpdateTodo,
+	CommandValidate,
+	CommandClearCache,
+	CommandRestartChecker,
+	CommandCreateBaseline,
+	CommandRefreshBaseline,
+}
+
+// CommandShowLocations is the client-side command run when a code lens is clicked.
+// Its arguments are the URI and position of the lens followed by the locations it counts.
+const CommandShowLocations = "wpks.showLocations"
+
+// MethodCurrentPackage is a custom notification telling the client which pack owns the opened file
+const MethodCurrentPackage = "wpks/currentPackage"
+
+// CurrentPackageParams is the payload of the wpks/currentPackage notification
+type CurrentPackageParams struct {
+	URI     protocol.DocumentUri `json:"uri"`
+	Package PackageInfo          `json:"package"`
+}
+
+// PackageInfo describes a pack for clients
+type PackageInfo struct {
+	Name   string `json:"name"`
+	Layer  string `json:"layer,omitempty"`
+	Owner  string `json:"owner,omitempty"`
+	IsRoot bool   `json:"isRoot"`
+}
+
+// ViolationData is the `data` of violation diagnostics, so code actions need not parse the message
+type ViolationData struct {
+	File               string `json:"file"`
+	Line               uint32 `json:"line"`      // 1-based, as reported by packwerk
+	Character          uint32 `json:"character"` // 0-based
+	Type               string `json:"type"`
+	Constant           string `json:"constant,omitempty"`
+	ReferencedPackage  string `json:"referencedPackage,omitempty"`
+	ReferencingPackage string `json:"referencingPackage,omitempty"`
+}
+
 // Notifier represents an interface that can send notifications
 type Notifier interface {
 	Notify(method string, params any)
 }
 
+// Requester represents an interface that can send requests to the client.
+// It must not be used from a request handler, which would deadlock the connection.
+type Requester interface {
+	Call(method string, params any, result any)
+}
+
 // ContextNotifier wraps glsp.Context to implement the Notifier interface
 type ContextNotifier struct {
 	ctx *glsp.Context
@@ -28,12 +90,29 @@ func (c *ContextNotifier) Notify(method string, params any) {
 	c.ctx.Notify(method, params)
 }
 
+// Call implements the Requester interface
+func (c *ContextNotifier) Call(method string, params any, result any) {
+	c.ctx.Call(method, params, result)
+}
+
 func NewInitializeResult(serverName string, serverVersion string) protocol.InitializeResult {
 	openClose := true
-	change := protocol.TextDocumentSyncKindNone
+	change := protocol.TextDocumentSyncKindFull
 	save := true
 	willSave := false
 	willSaveWaitUntil := false
+	definitionProvider := true
+	referencesProvider := true
+	hoverProvider := true
+	documentSymbolProvider := true
+	workspaceSymbolProvider := true
+	codeLensProvider := protocol.CodeLensOptions{}
+	executeCommandProvider := protocol.ExecuteCommandOptions{
+		Commands: Commands,
+	}
+	completionProvider := protocol.CompletionOptions{
+		TriggerCharacters: []string{" ", "/"},
+	}
 
 	return protocol.InitializeResult{
 		Capabilities: protocol.ServerCapabilities{
@@ -44,6 +123,14 @@ func NewInitializeResult(serverName string, serverVersion string) protocol.Initi
 				WillSave:          &willSave,
 				WillSaveWaitUntil: &willSaveWaitUntil,
 			},
+			CompletionProvider:      &completionProvider,
+			DefinitionProvider:      definitionProvider,
+			ReferencesProvider:      referencesProvider,
+			HoverProvider:           hoverProvider,
+			DocumentSymbolProvider:  documentSymbolProvider,
+			WorkspaceSymbolProvider: workspaceSymbolProvider,
+			CodeLensProvider:        &codeLensProvider,
+			ExecuteCommandProvider:  &executeCommandProvider,
 		},
 		ServerInfo: &protocol.InitializeResultServerInfo{
 			Name:    serverName,
@@ -52,6 +139,40 @@ func NewInitializeResult(serverName string, serverVersion string) protocol.Initi
 	}
 }
 
+// NewWatchedFilesRegistration asks the client to report changes to the files the pack registry indexes
+func NewWatchedFilesRegistration() protocol.RegistrationParams {
+	return protocol.RegistrationParams{
+		Registrations: []protocol.Registration{
+			{
+				ID:     "wpks-ls-watched-files",
+				Method: string(protocol.MethodWorkspaceDidChangeWatchedFiles),
+				RegisterOptions: protocol.DidChangeWatchedFilesRegistrationOptions{
+					Watchers: []protocol.FileSystemWatcher{
+						{GlobPattern: "**/" + domain.PackwerkConfigFile},
+						{GlobPattern: "**/" + domain.PackageConfigFile},
+						{GlobPattern: "**/" + domain.ProjectSettingsFile},
+						{GlobPattern: "**/" + domain.BaselineFile},
+						{GlobPattern: "**/*.rb"},
+					},
+				},
+			},
+		},
+	}
+}
+
+// NewConfigurationRegistration subscribes to workspace/didChangeConfiguration,
+// which clients pulling the configuration only send to registered servers
+func NewConfigurationRegistration() protocol.RegistrationParams {
+	return protocol.RegistrationParams{
+		Registrations: []protocol.Registration{
+			{
+				ID:     "wpks-ls-configuration",
+				Method: string(protocol.MethodWorkspaceDidChangeConfiguration),
+			},
+		},
+	}
+}
+
 func NotifyServerWindowWorkDoneProgressCreate(notifier Notifier, token string) {
 	progressToken := protocol.ProgressToken{Value: token}
 
@@ -122,6 +243,44 @@ func NotifyPublishDiagnostics(notifier Notifier, uri string, diagnostics []domai
 	)
 }
 
+// RequestCodeLensRefresh asks the client to request the code lenses again
+func RequestCodeLensRefresh(requester Requester) {
+	requester.Call(string(protocol.ServerWorkspaceCodeLensRefresh), nil, nil)
+}
+
+// RequestConfiguration pulls the `wpks` section of the client configuration
+func RequestConfiguration(requester Requester) any {
+	section := settingsSection
+	var result []any
+	requester.Call(string(protocol.ServerWorkspaceConfiguration), protocol.ConfigurationParams{
+		Items: []protocol.ConfigurationItem{{Section: &section}},
+	}, &result)
+	if len(result) == 0 {
+		return nil
+	}
+	return result[0]
+}
+
+// RequestApplyEdit asks the client to apply the edit and reports whether it was applied
+func RequestApplyEdit(requester Requester, edit *domain.WorkspaceEdit) protocol.ApplyWorkspaceEditResponse {
+	var response protocol.ApplyWorkspaceEditResponse
+	requester.Call(string(protocol.ServerWorkspaceApplyEdit), protocol.ApplyWorkspaceEditParams{
+		Label: &edit.Label,
+		Edit:  MapWorkspaceEdit(edit),
+	}, &response)
+	return response
+}
+
+func NotifyCurrentPackage(notifier Notifier, uri string, pkg *domain.Package) {
+	notifier.Notify(
+		MethodCurrentPackage,
+		CurrentPackageParams{
+			URI:     protocol.DocumentUri(uri),
+			Package: MapPackageInfo(pkg),
+		},
+	)
+}
+
 func NotifyErrorLogMessage(notifier Notifier, format string, args ...any) {
 	notifier.Notify(
 		protocol.ServerWindowLogMessage,
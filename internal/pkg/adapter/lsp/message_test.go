@@ -19,6 +19,21 @@ func (m *MockNotifier) Notify(method string, params any) {
 	m.NotifiedParams = append(m.NotifiedParams, params)
 }
 
+// MockRequester implements Requester for testing, answering every request with result
+type MockRequester struct {
+	CalledMethods []string
+	CalledParams  []any
+	Result        any
+}
+
+func (m *MockRequester) Call(method string, params any, result any) {
+	m.CalledMethods = append(m.CalledMethods, method)
+	m.CalledParams = append(m.CalledParams, params)
+	if result != nil && m.Result != nil {
+		reflect.ValueOf(result).Elem().Set(reflect.ValueOf(m.Result))
+	}
+}
+
 // Tests for ContextNotifier
 func TestNewContextNotifier(t *testing.T) {
 	t.Run("create context notifier", func(t *testing.T) {
@@ -68,11 +83,23 @@ func TestNewInitializeResult(t *testing.T) {
 				Capabilities: protocol.ServerCapabilities{
 					TextDocumentSync: &protocol.TextDocumentSyncOptions{
 						OpenClose:         ptrBool(true),
-						Change:            ptrTextDocumentSyncKind(protocol.TextDocumentSyncKindNone),
+						Change:            ptrTextDocumentSyncKind(protocol.TextDocumentSyncKindFull),
 						Save:              ptrBool(true),
 						WillSave:          ptrBool(false),
 						WillSaveWaitUntil: ptrBool(false),
 					},
+					CompletionProvider: &protocol.CompletionOptions{
+						TriggerCharacters: []string{" ", "/"},
+					},
+					DefinitionProvider:      true,
+					ReferencesProvider:      true,
+					HoverProvider:           true,
+					DocumentSymbolProvider:  true,
+					WorkspaceSymbolProvider: true,
+					CodeLensProvider:        &protocol.CodeLensOptions{},
+					ExecuteCommandProvider: &protocol.ExecuteCommandOptions{
+						Commands: []string{"wpks.checkAll", "wpks.checkFile", "wpks.updateTodo", "wpks.validate", "wpks.clearCache", "wpks.restartChecker", "wpks.createBaseline", "wpks.refreshBaseline"},
+					},
 				},
 				ServerInfo: &protocol.InitializeResultServerInfo{
 					Name:    "test-server",
@@ -88,11 +115,23 @@ func TestNewInitializeResult(t *testing.T) {
 				Capabilities: protocol.ServerCapabilities{
 					TextDocumentSync: &protocol.TextDocumentSyncOptions{
 						OpenClose:         ptrBool(true),
-						Change:            ptrTextDocumentSyncKind(protocol.TextDocumentSyncKindNone),
+						Change:            ptrTextDocumentSyncKind(protocol.TextDocumentSyncKindFull),
 						Save:              ptrBool(true),
 						WillSave:          ptrBool(false),
 						WillSaveWaitUntil: ptrBool(false),
 					},
+					CompletionProvider: &protocol.CompletionOptions{
+						TriggerCharacters: []string{" ", "/"},
+					},
+					DefinitionProvider:      true,
+					ReferencesProvider:      true,
+					HoverProvider:           true,
+					DocumentSymbolProvider:  true,
+					WorkspaceSymbolProvider: true,
+					CodeLensProvider:        &protocol.CodeLensOptions{},
+					ExecuteCommandProvider: &protocol.ExecuteCommandOptions{
+						Commands: []string{"wpks.checkAll", "wpks.checkFile", "wpks.updateTodo", "wpks.validate", "wpks.clearCache", "wpks.restartChecker", "wpks.createBaseline", "wpks.refreshBaseline"},
+					},
 				},
 				ServerInfo: &protocol.InitializeResultServerInfo{
 					Name:    "",
@@ -112,6 +151,60 @@ func TestNewInitializeResult(t *testing.T) {
 	}
 }
 
+func TestNewWatchedFilesRegistration(t *testing.T) {
+	got := NewWatchedFilesRegistration()
+	if len(got.Registrations) != 1 {
+		t.Fatalf("expected 1 registration, got %d", len(got.Registrations))
+	}
+	registration := got.Registrations[0]
+	if registration.Method != string(protocol.MethodWorkspaceDidChangeWatchedFiles) {
+		t.Errorf("unexpected method: %s", registration.Method)
+	}
+	options, ok := registration.RegisterOptions.(protocol.DidChangeWatchedFilesRegistrationOptions)
+	if !ok {
+		t.Fatalf("expected DidChangeWatchedFilesRegistrationOptions, got %T", registration.RegisterOptions)
+	}
+	var patterns []string
+	for _, watcher := range options.Watchers {
+		patterns = append(patterns, watcher.GlobPattern)
+	}
+	want := []string{"**/packwerk.yml", "**/package.yml", "**/.wpks-ls.yml", "**/.wpks-ls-baseline.json", "**/*.rb"}
+	if !reflect.DeepEqual(patterns, want) {
+		t.Errorf("want %v, got %v", want, patterns)
+	}
+}
+
+func TestNewConfigurationRegistration(t *testing.T) {
+	got := NewConfigurationRegistration()
+	if len(got.Registrations) != 1 {
+		t.Fatalf("expected 1 registration, got %d", len(got.Registrations))
+	}
+	if method := got.Registrations[0].Method; method != string(protocol.MethodWorkspaceDidChangeConfiguration) {
+		t.Errorf("unexpected method: %s", method)
+	}
+}
+
+func TestRequestConfiguration(t *testing.T) {
+	section := map[string]any{"severity": "warning"}
+	requester := &MockRequester{Result: []any{section}}
+
+	got := RequestConfiguration(requester)
+	if !reflect.DeepEqual(got, section) {
+		t.Errorf("want %v, got %v", section, got)
+	}
+	if len(requester.CalledMethods) != 1 || requester.CalledMethods[0] != string(protocol.ServerWorkspaceConfiguration) {
+		t.Fatalf("unexpected requests: %v", requester.CalledMethods)
+	}
+	params := requester.CalledParams[0].(protocol.ConfigurationParams)
+	if len(params.Items) != 1 || *params.Items[0].Section != "wpks" {
+		t.Errorf("unexpected params: %+v", params)
+	}
+
+	if got := RequestConfiguration(&MockRequester{}); got != nil {
+		t.Errorf("want no section when the client answers nothing, got %v", got)
+	}
+}
+
 func TestNotifyServerWindowWorkDoneProgressCreate(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -411,6 +504,28 @@ func TestNotifyPublishDiagnostics(t *testing.T) {
 	}
 }
 
+func TestNotifyCurrentPackage(t *testing.T) {
+	mockNotifier := &MockNotifier{}
+	pkg := &domain.Package{Name: "packs/books", Layer: "utility", Owner: "Library"}
+
+	NotifyCurrentPackage(mockNotifier, "file:///root/packs/books/app/models/book.rb", pkg)
+
+	if len(mockNotifier.NotifiedMethods) != 1 {
+		t.Fatalf("expected 1 notification, got %d", len(mockNotifier.NotifiedMethods))
+	}
+	if mockNotifier.NotifiedMethods[0] != MethodCurrentPackage {
+		t.Errorf("expected method %s, got %s", MethodCurrentPackage, mockNotifier.NotifiedMethods[0])
+	}
+
+	want := CurrentPackageParams{
+		URI:     "file:///root/packs/books/app/models/book.rb",
+		Package: PackageInfo{Name: "packs/books", Layer: "utility", Owner: "Library", IsRoot: false},
+	}
+	if !reflect.DeepEqual(mockNotifier.NotifiedParams[0], want) {
+		t.Errorf("want %+v, got %+v", want, mockNotifier.NotifiedParams[0])
+	}
+}
+
 func TestNotifyErrorLogMessage(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -2,6 +2,15 @@ package lsp
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log"
+	"maps"
+	"net/url"
+	"path/filepath"
+	"slices"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
@@ -17,6 +26,9 @@ const (
 	serverName    = "wpks-ls"
 	serverVersion = "0.0.1"
 	diagnoseTopic = "diagnose"
+	refreshTopic  = "refresh"
+	commandTopic  = "command"
+	settingsTopic = "settings"
 )
 
 // DiagnoseType represents the type of diagnosis to perform
@@ -25,34 +37,100 @@ type DiagnoseType int
 const (
 	DiagnoseFile DiagnoseType = iota // Diagnose a single file
 	DiagnoseAll                      // Diagnose all files
+	ValidateAll                      // Validate all opened config files
+	RebuildAll                       // Rebuild the diagnostics of known violations after the settings changed
 )
 
 // Message represents a message containing glsp.Context and URI
 type Message struct {
 	notifier Notifier
 	URI      string
+	URIs     []string // set for messages of the refresh topic, one per notification
 	Type     DiagnoseType
+	Command  string // set for messages of the command topic
+	Settings any    // settings pushed by the client, for messages of the settings topic
 	// Additional fields can be added here as needed
 }
 
+// Usecases bundles the input ports the server dispatches requests to.
+type Usecases struct {
+	DiagnoseFile        in.DiagnoseFile
+	CreateWorkspace     in.CreateWorkspace
+	LoadPackages        in.LoadPackages
+	ResolvePackage      in.ResolvePackage
+	SyncDocument        in.SyncDocument
+	FindDefinition      in.FindDefinition
+	FindReferences      in.FindReferences
+	ShowHover           in.ShowHover
+	Complete            in.Complete
+	ValidatePackage     in.ValidatePackage
+	SearchSymbols       in.SearchSymbols
+	ListDocumentSymbols in.ListDocumentSymbols
+	ListCodeLenses      in.ListCodeLenses
+	ManageChecker       in.ManageChecker
+	ManageBaseline      in.ManageBaseline
+	ConfigureSettings   in.ConfigureSettings
+}
+
 // Server represents a minimal LSP server.
 type Server struct {
-	diagnoseFile    in.DiagnoseFile
-	createWorkspace in.CreateWorkspace
-	messageQueue    task.Broker[Message]
-	options         *ServerOptions
+	usecases      Usecases
+	messageQueue  task.Broker[Message]
+	options       *ServerOptions
+	canWatchFiles bool
+	// canRefreshCodeLens is set when the client accepts workspace/codeLens/refresh
+	canRefreshCodeLens bool
+	// canApplyEdit is set when the client accepts workspace/applyEdit with documentChanges
+	canApplyEdit bool
+	// canPullSettings is set when the client answers workspace/configuration
+	canPullSettings bool
+	// canRegisterSettings is set when the client sends didChangeConfiguration to registered servers only
+	canRegisterSettings bool
+	// initializationOptions override .wpks-ls.yml, and the `wpks` section overrides both
+	initializationOptions any
+	sectionMu             sync.Mutex
+	section               any // the latest `wpks` section of the client configuration
+	// disconnected is set once the client went away and the queue is closed
+	disconnected atomic.Bool
+	// remoteRoot is set for clients connected over the network. Their workspace must be inside it,
+	// and they may not choose the commands run or the directories removed
+	remoteRoot string
 }
 
-func NewServer(diagnoseFile in.DiagnoseFile, createWorkspace in.CreateWorkspace) *Server {
+func NewServer(usecases Usecases) *Server {
 	messageQueue := task.NewMessageBroker[Message]()
 
 	server := &Server{
-		diagnoseFile:    diagnoseFile,
-		createWorkspace: createWorkspace,
-		messageQueue:    messageQueue,
-		options:         NewServerOptions(),
+		usecases:     usecases,
+		messageQueue: messageQueue,
+		options:      NewServerOptions(),
 	}
 
+	messageQueue.RegisterTopic(
+		diagnoseTopic,
+		server.handleDiagnose,
+		task.WithQueueSize(100),
+		// applySettings sets the batch of the settings
+		task.WithBatchConfig(server.options.Settings.BatchSize, server.options.Settings.BatchDelay),
+	)
+	messageQueue.RegisterTopic(
+		refreshTopic,
+		server.handleRefresh,
+		task.WithQueueSize(1000),
+		task.WithBatchConfig(100, 500*time.Millisecond),
+	)
+	messageQueue.RegisterTopic(
+		commandTopic,
+		server.handleCommand,
+		task.WithQueueSize(10),
+	)
+	messageQueue.RegisterTopic(
+		settingsTopic,
+		server.handleSettings,
+		task.WithQueueSize(10),
+		task.WithBatchConfig(10, 100*time.Millisecond),
+	)
+
 	return server
 }
 
@@ -67,13 +145,24 @@ func (s *Server) handleDiagnose(ctx context.Context, msgs []Message) {
 	NotifyServerWindowWorkDoneProgressCreate(notifier, token)
 
 	hasAll := false
+	hasValidateAll := false
+	hasRebuild := false
 	uriSet := make(map[string]struct{})
+	configUriSet := make(map[string]struct{})
 	for _, msg := range msgs {
 		switch msg.Type {
 		case DiagnoseFile:
-			uriSet[msg.URI] = struct{}{}
+			if domain.IsConfigFile(msg.URI) || domain.IsProjectSettingsFile(msg.URI) {
+				configUriSet[msg.URI] = struct{}{}
+			} else {
+				uriSet[msg.URI] = struct{}{}
+			}
 		case DiagnoseAll:
 			hasAll = true
+		case ValidateAll:
+			hasValidateAll = true
+		case RebuildAll:
+			hasRebuild = true
 		}
 	}
 
@@ -84,17 +173,35 @@ func (s *Server) handleDiagnose(ctx context.Context, msgs []Message) {
 		NotifyBeginProgress(notifier, token, "Diagnosing all files...", false)
 		NotifyReportProgress(notifier, token, "Diagnosing...", 25)
 
-		allResults, err = s.diagnoseFile.DiagnoseAll(ctx)
+		allResults, err = s.usecases.DiagnoseFile.DiagnoseAll(ctx)
 	} else {
 		NotifyBeginProgress(notifier, token, "Diagnosing files...", false)
 		NotifyReportProgress(notifier, token, "Diagnosing...", 25)
 
-		uris := make([]string, 0, len(uriSet))
-		for uri := range uriSet {
-			uris = append(uris, uri)
+		allResults, err = s.usecases.DiagnoseFile.Diagnose(ctx, slices.Collect(maps.Keys(uriSet))...)
+
+		// A full check already applies the current settings
+		if err == nil && hasRebuild {
+			var rebuiltResults map[string][]domain.Diagnostic
+			rebuiltResults, err = s.usecases.DiagnoseFile.Rebuild()
+			for uri, diagnostics := range rebuiltResults {
+				if _, ok := allResults[uri]; !ok {
+					allResults[uri] = diagnostics
+				}
+			}
 		}
+	}
 
-		allResults, err = s.diagnoseFile.Diagnose(ctx, uris...)
+	if err == nil && (len(configUriSet) > 0 || hasValidateAll) {
+		var configResults map[string][]domain.Diagnostic
+		if hasValidateAll {
+			configResults, err = s.usecases.ValidatePackage.ValidateAll(ctx)
+		} else {
+			configResults, err = s.usecases.ValidatePackage.Validate(ctx, slices.Collect(maps.Keys(configUriSet))...)
+		}
+		for uri, diagnostics := range configResults {
+			allResults[uri] = diagnostics
+		}
 	}
 
 	if err != nil {
@@ -105,73 +212,358 @@ func (s *Server) handleDiagnose(ctx context.Context, msgs []Message) {
 		for uri, diagnostics := range allResults {
 			NotifyPublishDiagnostics(notifier, uri, diagnostics)
 		}
+
+		// Code lenses count the violations that were just found
+		if requester, ok := notifier.(Requester); ok && s.canRefreshCodeLens {
+			RequestCodeLensRefresh(requester)
+		}
 	}
 
 	NotifyReportProgress(notifier, token, "Diagnosing...", 100)
 	NotifyEndProgress(notifier, token, "Diagnosis complete")
 }
 
-// Start runs the LSP server loop.
-func (s *Server) Start() error {
-	handler := protocol.Handler{
-		Initialize:           s.onInitialize,
-		Initialized:          s.onInitialized,
-		Shutdown:             s.onShutdown,
-		TextDocumentDidOpen:  s.onTextDocumentDidOpen,
-		TextDocumentDidSave:  s.onTextDocumentDidSave,
-		TextDocumentDidClose: s.onTextDocumentDidClose,
+// handleRefresh reloads the pack registry after watched files changed
+func (s *Server) handleRefresh(ctx context.Context, msgs []Message) {
+	if len(msgs) == 0 {
+		return
 	}
-	ls := server.NewServer(&handler, serverName, false)
+	notifier := msgs[len(msgs)-1].notifier
 
-	return ls.RunStdio()
+	uris := make([]string, 0, len(msgs))
+	settingsChanged := false
+	baselineChanged := false
+	for _, msg := range msgs {
+		for _, uri := range msg.URIs {
+			uris = append(uris, uri)
+			settingsChanged = settingsChanged || domain.IsProjectSettingsFile(uri)
+			baselineChanged = baselineChanged || domain.IsBaselineFile(uri)
+		}
+	}
+
+	if baselineChanged {
+		s.validateBaseline(notifier)
+	}
+	if settingsChanged {
+		s.enqueue(settingsTopic, Message{notifier: notifier})
+	} else if baselineChanged {
+		// Applying the settings rebuilds the diagnostics as well
+		s.enqueue(diagnoseTopic, Message{notifier: notifier, Type: RebuildAll})
+	}
+
+	if err := s.usecases.LoadPackages.Refresh(uris...); err != nil {
+		NotifyWarningLogMessage(notifier, "Failed to refresh packages: %v", err)
+	}
 }
 
-func (s *Server) onInitialize(ctx *glsp.Context, params *protocol.InitializeParams) (any, error) {
+// handleCommand runs the maintenance commands one at a time
+func (s *Server) handleCommand(ctx context.Context, msgs []Message) {
+	for _, msg := range msgs {
+		switch msg.Command {
+		case CommandUpdateTodo:
+			s.updateTodo(ctx, msg)
+		case CommandClearCache:
+			if err := s.usecases.ManageChecker.ClearCache(); err != nil {
+				NotifyErrorLogMessage(msg.notifier, "Failed to clear the packwerk cache: %v", err)
+				continue
+			}
+			NotifyInfoLogMessage(msg.notifier, "Cleared the packwerk cache")
+		case CommandRestartChecker:
+			if err := s.usecases.ManageChecker.Restart(); err != nil {
+				NotifyErrorLogMessage(msg.notifier, "Failed to restart the checker: %v", err)
+				continue
+			}
+			NotifyInfoLogMessage(msg.notifier, "Restarted the checker")
+		case CommandCreateBaseline, CommandRefreshBaseline:
+			s.updateBaseline(ctx, msg)
+		}
+	}
+}
+
+// validateBaseline warns about a broken .wpks-ls-baseline.json, whose violations are reported as new meanwhile
+func (s *Server) validateBaseline(notifier Notifier) {
+	if err := s.usecases.ManageBaseline.ValidateBaseline(); err != nil {
+		NotifyWarningLogMessage(notifier, "%v, every violation is reported as new", err)
+	}
+}
+
+// updateBaseline checks the whole project and writes .wpks-ls-baseline.json
+func (s *Server) updateBaseline(ctx context.Context, msg Message) {
+	update, verb := s.usecases.ManageBaseline.CreateBaseline, "Created"
+	if msg.Command == CommandRefreshBaseline {
+		update, verb = s.usecases.ManageBaseline.RefreshBaseline, "Refreshed"
+	}
+
+	token := uuid.New().String()
+	NotifyServerWindowWorkDoneProgressCreate(msg.notifier, token)
+	NotifyBeginProgress(msg.notifier, token, "Checking all files for the baseline...", false)
+	count, err := update(ctx)
+	NotifyEndProgress(msg.notifier, token, "Baseline complete")
+	if err != nil {
+		NotifyErrorLogMessage(msg.notifier, "Failed to update %s: %v", domain.BaselineFile, err)
+		return
+	}
+	NotifyInfoLogMessage(msg.notifier, "%s %s with %d known violations", verb, domain.BaselineFile, count)
+	// The violations of the full check are stored, so they only need to be matched against the baseline
+	s.enqueue(diagnoseTopic, Message{notifier: msg.notifier, Type: RebuildAll})
+}
+
+// updateTodo runs update-todo and lets the user review the changes before they are applied
+func (s *Server) updateTodo(ctx context.Context, msg Message) {
+	requester, ok := msg.notifier.(Requester)
+	if !ok || !s.canApplyEdit {
+		NotifyErrorLogMessage(msg.notifier, "The client cannot apply workspace edits, run packwerk update-todo instead")
+		return
+	}
+
+	token := uuid.New().String()
+	NotifyServerWindowWorkDoneProgressCreate(msg.notifier, token)
+	NotifyBeginProgress(msg.notifier, token, "Updating package_todo.yml...", false)
+	edit, err := s.usecases.ManageChecker.UpdateTodo(ctx, msg.URI)
+	NotifyEndProgress(msg.notifier, token, "Update complete")
+	if err != nil {
+		NotifyErrorLogMessage(msg.notifier, "Failed to update package_todo.yml: %v", err)
+		return
+	}
+	if edit.IsEmpty() {
+		NotifyInfoLogMessage(msg.notifier, "package_todo.yml is up to date")
+		return
+	}
+
+	response := RequestApplyEdit(requester, edit)
+	if !response.Applied {
+		if response.FailureReason != nil {
+			NotifyInfoLogMessage(msg.notifier, "package_todo.yml was not updated: %s", *response.FailureReason)
+		}
+		return
+	}
+	// Violations listed in the todo files are no longer reported
+	s.enqueue(diagnoseTopic, Message{notifier: msg.notifier, Type: DiagnoseAll})
+}
+
+// handleSettings applies the latest configuration of the client and of .wpks-ls.yml
+func (s *Server) handleSettings(ctx context.Context, msgs []Message) {
+	if len(msgs) == 0 {
+		return
+	}
+	notifier := msgs[len(msgs)-1].notifier
+
+	if requester, ok := notifier.(Requester); ok && s.canPullSettings {
+		s.setSection(RequestConfiguration(requester))
+	} else {
+		// Messages about a changed .wpks-ls.yml carry no settings
+		for _, msg := range msgs {
+			if msg.Settings != nil {
+				s.setSection(settingsOf(msg.Settings))
+			}
+		}
+	}
+
+	if s.applySettings(notifier) == nil {
+		return
+	}
+	s.enqueue(diagnoseTopic, Message{notifier: notifier, Type: RebuildAll})
+}
+
+func (s *Server) setSection(section any) {
+	s.sectionMu.Lock()
+	defer s.sectionMu.Unlock()
+	s.section = section
+}
+
+// applySettings merges .wpks-ls.yml, the initialization options and the `wpks` section, in this order.
+// It returns nil when the settings are invalid, in which case the previous ones are kept.
+func (s *Server) applySettings(notifier Notifier) *ServerOptions {
 	options := NewServerOptions()
-	options.Apply(params.InitializationOptions)
 
-	// Store the parsed options in the server
-	s.options = options
+	settings, settingsErrors, err := s.usecases.ConfigureSettings.ProjectSettings()
+	if err != nil {
+		NotifyWarningLogMessage(notifier, "Failed to read %s: %v", domain.ProjectSettingsFile, err)
+	} else {
+		options.Settings = settings
+	}
+	for _, settingsError := range settingsErrors {
+		NotifyWarningLogMessage(notifier, "%s:%d: %s", domain.ProjectSettingsFile, settingsError.Range.Start.Line+1, settingsError.Message)
+	}
 
-	err := s.createWorkspace.Create(*params.RootURI, *params.RootPath)
+	s.sectionMu.Lock()
+	section := s.section
+	s.sectionMu.Unlock()
+
+	command, cacheDirectory := options.Settings.CheckerCommand, options.Settings.CacheDirectory
+	options.Apply(s.initializationOptions)
+	options.Apply(section)
+	// Anyone reaching the listener could run any program or remove any directory as the server otherwise
+	if s.remoteRoot != "" && !slices.Equal(command, options.Settings.CheckerCommand) {
+		options.Settings.CheckerCommand = command
+		NotifyWarningLogMessage(notifier, "Ignored checkerCommand of a network client. Set it in %s instead", domain.ProjectSettingsFile)
+	}
+	if s.remoteRoot != "" && cacheDirectory != options.Settings.CacheDirectory {
+		options.Settings.CacheDirectory = cacheDirectory
+		NotifyWarningLogMessage(notifier, "Ignored cacheDirectory of a network client. Set it in %s instead", domain.ProjectSettingsFile)
+	}
+
+	if err := s.usecases.ConfigureSettings.Configure(options.Settings); err != nil {
+		NotifyWarningLogMessage(notifier, "Invalid %s settings: %v", settingsSection, err)
+		return nil
+	}
+	// Files opened or saved next are batched with the new settings
+	s.messageQueue.SetBatchConfig(diagnoseTopic, options.Settings.BatchSize, options.Settings.BatchDelay)
+	return options
+}
+
+// Start runs the LSP server loop on stdio.
+func (s *Server) Start() error {
+	ls := server.NewServer(s.newHandler(), serverName, false)
+
+	return ls.RunStdio()
+}
+
+func (s *Server) newHandler() *protocol.Handler {
+	return &protocol.Handler{
+		Initialize:                 s.onInitialize,
+		Initialized:                s.onInitialized,
+		Shutdown:                   s.onShutdown,
+		TextDocumentDidOpen:        s.onTextDocumentDidOpen,
+		TextDocumentDidChange:      s.onTextDocumentDidChange,
+		TextDocumentDidSave:        s.onTextDocumentDidSave,
+		TextDocumentDidClose:       s.onTextDocumentDidClose,
+		TextDocumentDefinition:     s.onTextDocumentDefinition,
+		TextDocumentReferences:     s.onTextDocumentReferences,
+		TextDocumentHover:          s.onTextDocumentHover,
+		TextDocumentCompletion:     s.onTextDocumentCompletion,
+		TextDocumentDocumentSymbol: s.onTextDocumentDocumentSymbol,
+		TextDocumentCodeLens:       s.onTextDocumentCodeLens,
+
+		WorkspaceSymbol:                 s.onWorkspaceSymbol,
+		WorkspaceExecuteCommand:         s.onWorkspaceExecuteCommand,
+		WorkspaceDidChangeWatchedFiles:  s.onWorkspaceDidChangeWatchedFiles,
+		WorkspaceDidChangeConfiguration: s.onWorkspaceDidChangeConfiguration,
+	}
+}
+
+// enqueue adds the message to the topic unless its queue is full, which the client is told about.
+// Handlers use it rather than Enqueue, which panics and would take the connection down.
+func (s *Server) enqueue(topic string, msg Message) bool {
+	if s.messageQueue.TryEnqueue(topic, msg) {
+		return true
+	}
+	if s.disconnected.Load() {
+		// Nobody is left to tell, e.g. when the first check is queued after the client went away
+		return false
+	}
+	NotifyWarningLogMessage(msg.notifier, "Dropped a %s request, the server is busy", topic)
+	return false
+}
+
+// disconnect releases the connection of a client that went away, maybe without shutting down.
+func (s *Server) disconnect() {
+	s.disconnected.Store(true)
+	// Jobs waiting for the client return once their requests fail
+	s.messageQueue.Stop()
+	if s.usecases.ManageChecker != nil {
+		// Checks in flight would only report to a closed connection
+		_ = s.usecases.ManageChecker.Restart()
+	}
+}
+
+func (s *Server) onInitialize(ctx *glsp.Context, params *protocol.InitializeParams) (any, error) {
+	s.canWatchFiles = supportsWatchedFilesRegistration(params.Capabilities)
+	s.canRefreshCodeLens = supportsCodeLensRefresh(params.Capabilities)
+	s.canApplyEdit = supportsApplyEdit(params.Capabilities)
+	s.canPullSettings = supportsConfiguration(params.Capabilities)
+	s.canRegisterSettings = supportsConfigurationRegistration(params.Capabilities)
+	s.initializationOptions = params.InitializationOptions
+
+	rootUri, rootPath, err := workspaceRoot(params)
+	if err != nil {
+		return nil, err
+	}
+	if s.remoteRoot != "" && !isInside(s.remoteRoot, rootPath) {
+		return nil, fmt.Errorf("the workspace %s is outside of %s, which the server was started for", rootPath, s.remoteRoot)
+	}
+	err = s.usecases.CreateWorkspace.Create(rootUri, rootPath)
 	if err != nil {
 		return nil, err
 	}
 
-	s.messageQueue.RegisterTopic(
-		diagnoseTopic,
-		s.handleDiagnose,
-		task.WithQueueSize(100),
-		task.WithBatchConfig(10, 100*time.Millisecond),
-	)
+	// Store the parsed options in the server
+	if options := s.applySettings(NewContextNotifier(ctx)); options != nil {
+		s.options = options
+	}
+
+	// A broken package.yml should not prevent the server from starting
+	if err := s.usecases.LoadPackages.Load(); err != nil {
+		NotifyWarningLogMessage(NewContextNotifier(ctx), "Failed to load packages: %v", err)
+	}
 
 	s.messageQueue.Start(context.Background())
 
 	return NewInitializeResult(serverName, serverVersion), nil
 }
 
+// onShutdown cancels the jobs in flight without waiting for them. They may wait for a reply
+// of the client, which is only read once this handler returned.
 func (s *Server) onShutdown(ctx *glsp.Context) error {
-	s.messageQueue.Close()
+	s.messageQueue.Stop()
 	return nil
 }
 
 func (s *Server) onInitialized(ctx *glsp.Context, params *protocol.InitializedParams) error {
-	if s.options.CheckAllOnInitialized {
-		s.messageQueue.Enqueue(diagnoseTopic, Message{
-			notifier: NewContextNotifier(ctx),
-			URI:      "", // Not applicable for "diagnose all"
-			Type:     DiagnoseAll,
-		})
-	}
+	notifier := NewContextNotifier(ctx)
+
+	// Requests to the client must not block the handler, which would deadlock the connection
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				log.Printf("%s: initialization failed: %v", serverName, r)
+			}
+		}()
+
+		if s.canWatchFiles {
+			ctx.Call(protocol.ServerClientRegisterCapability, NewWatchedFilesRegistration(), nil)
+		}
+		if s.canRegisterSettings {
+			ctx.Call(protocol.ServerClientRegisterCapability, NewConfigurationRegistration(), nil)
+		}
+
+		// The first check must already run with the settings of the client
+		options := s.options
+		if s.canPullSettings {
+			s.setSection(RequestConfiguration(notifier))
+			if pulled := s.applySettings(notifier); pulled != nil {
+				options = pulled
+			}
+		}
+
+		s.validateBaseline(notifier)
+
+		if options.CheckAllOnInitialized {
+			s.enqueue(diagnoseTopic, Message{
+				notifier: notifier,
+				URI:      "", // Not applicable for "diagnose all"
+				Type:     DiagnoseAll,
+			})
+		}
+	}()
 
 	return nil
 }
 
 func (s *Server) onTextDocumentDidOpen(ctx *glsp.Context, params *protocol.DidOpenTextDocumentParams) error {
 	uri := string(params.TextDocument.URI)
+	notifier := NewContextNotifier(ctx)
 
-	s.messageQueue.Enqueue(diagnoseTopic, Message{
-		notifier: NewContextNotifier(ctx),
+	if err := s.usecases.SyncDocument.Open(uri, params.TextDocument.Text); err != nil {
+		return err
+	}
+
+	if pkg, err := s.usecases.ResolvePackage.Resolve(uri); err == nil {
+		NotifyCurrentPackage(notifier, uri, pkg)
+	}
+
+	s.enqueue(diagnoseTopic, Message{
+		notifier: notifier,
 		URI:      uri,
 		Type:     DiagnoseFile,
 	})
@@ -181,7 +573,18 @@ func (s *Server) onTextDocumentDidOpen(ctx *glsp.Context, params *protocol.DidOp
 func (s *Server) onTextDocumentDidSave(ctx *glsp.Context, params *protocol.DidSaveTextDocumentParams) error {
 	uri := string(params.TextDocument.URI)
 
-	s.messageQueue.Enqueue(diagnoseTopic, Message{
+	// Other packs are validated against what is on disk, so pick up the saved config
+	if domain.IsConfigFile(uri) {
+		if err := s.usecases.LoadPackages.Load(); err != nil {
+			NotifyWarningLogMessage(NewContextNotifier(ctx), "Failed to load packages: %v", err)
+		}
+	}
+	// Clients watching files report the change of .wpks-ls.yml already
+	if domain.IsProjectSettingsFile(uri) && !s.canWatchFiles {
+		s.enqueue(settingsTopic, Message{notifier: NewContextNotifier(ctx)})
+	}
+
+	s.enqueue(diagnoseTopic, Message{
 		notifier: NewContextNotifier(ctx),
 		URI:      uri,
 		Type:     DiagnoseFile,
@@ -189,6 +592,217 @@ func (s *Server) onTextDocumentDidSave(ctx *glsp.Context, params *protocol.DidSa
 	return nil
 }
 
+func (s *Server) onTextDocumentDidChange(ctx *glsp.Context, params *protocol.DidChangeTextDocumentParams) error {
+	uri := string(params.TextDocument.URI)
+
+	// Full sync: the last change holds the whole text
+	for _, change := range params.ContentChanges {
+		if whole, ok := change.(protocol.TextDocumentContentChangeEventWhole); ok {
+			if err := s.usecases.SyncDocument.Change(uri, whole.Text); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func (s *Server) onTextDocumentDidClose(ctx *glsp.Context, params *protocol.DidCloseTextDocumentParams) error {
+	return s.usecases.SyncDocument.Close(string(params.TextDocument.URI))
+}
+
+func (s *Server) onTextDocumentDefinition(ctx *glsp.Context, params *protocol.DefinitionParams) (any, error) {
+	locations, err := s.usecases.FindDefinition.Definition(
+		string(params.TextDocument.URI),
+		domain.Position{Line: params.Position.Line, Character: params.Position.Character},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return MapLocations(locations), nil
+}
+
+func (s *Server) onTextDocumentReferences(ctx *glsp.Context, params *protocol.ReferenceParams) ([]protocol.Location, error) {
+	locations, err := s.usecases.FindReferences.References(
+		string(params.TextDocument.URI),
+		domain.Position{Line: params.Position.Line, Character: params.Position.Character},
+		params.Context.IncludeDeclaration,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return MapLocations(locations), nil
+}
+
+func (s *Server) onTextDocumentHover(ctx *glsp.Context, params *protocol.HoverParams) (*protocol.Hover, error) {
+	hover, err := s.usecases.ShowHover.Hover(
+		string(params.TextDocument.URI),
+		domain.Position{Line: params.Position.Line, Character: params.Position.Character},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return MapHover(hover), nil
+}
+
+func (s *Server) onTextDocumentCompletion(ctx *glsp.Context, params *protocol.CompletionParams) (any, error) {
+	items, err := s.usecases.Complete.Complete(
+		string(params.TextDocument.URI),
+		domain.Position{Line: params.Position.Line, Character: params.Position.Character},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return MapCompletionItems(items), nil
+}
+
+func (s *Server) onTextDocumentDocumentSymbol(ctx *glsp.Context, params *protocol.DocumentSymbolParams) (any, error) {
+	symbols, err := s.usecases.ListDocumentSymbols.DocumentSymbols(string(params.TextDocument.URI))
+	if err != nil {
+		return nil, err
+	}
+	return MapDocumentSymbols(symbols), nil
+}
+
+func (s *Server) onTextDocumentCodeLens(ctx *glsp.Context, params *protocol.CodeLensParams) ([]protocol.CodeLens, error) {
+	uri := string(params.TextDocument.URI)
+	codeLenses, err := s.usecases.ListCodeLenses.CodeLenses(uri)
+	if err != nil {
+		return nil, err
+	}
+	return MapCodeLenses(uri, codeLenses), nil
+}
+
+func (s *Server) onWorkspaceSymbol(ctx *glsp.Context, params *protocol.WorkspaceSymbolParams) ([]protocol.SymbolInformation, error) {
+	symbols, err := s.usecases.SearchSymbols.Search(params.Query)
+	if err != nil {
+		return nil, err
+	}
+	return MapSymbols(symbols), nil
+}
+
+func (s *Server) onWorkspaceExecuteCommand(ctx *glsp.Context, params *protocol.ExecuteCommandParams) (any, error) {
+	notifier := NewContextNotifier(ctx)
+
+	topic, msg := diagnoseTopic, Message{notifier: notifier}
+	switch params.Command {
+	case CommandCheckAll:
+		msg.Type = DiagnoseAll
+	case CommandCheckFile:
+		uri, ok := stringArgument(params.Arguments, 0)
+		if !ok {
+			return nil, fmt.Errorf("%s expects the URI of the file to check", params.Command)
+		}
+		msg.URI, msg.Type = uri, DiagnoseFile
+	case CommandValidate:
+		// Other packs are validated against what is on disk, so pick up recent changes
+		if err := s.usecases.LoadPackages.Load(); err != nil {
+			NotifyWarningLogMessage(notifier, "Failed to load packages: %v", err)
+		}
+		if uri, ok := stringArgument(params.Arguments, 0); ok {
+			msg.URI, msg.Type = uri, DiagnoseFile
+		} else {
+			msg.Type = ValidateAll
+		}
+	case CommandUpdateTodo:
+		// Without a URI every pack is updated
+		uri, _ := stringArgument(params.Arguments, 0)
+		topic, msg.URI, msg.Command = commandTopic, uri, params.Command
+	case CommandClearCache, CommandRestartChecker, CommandCreateBaseline, CommandRefreshBaseline:
+		topic, msg.Command = commandTopic, params.Command
+	default:
+		return nil, fmt.Errorf("unknown command: %s", params.Command)
+	}
+
+	// Commands run one at a time, so the client is told to retry rather than queueing them without end
+	if !s.messageQueue.TryEnqueue(topic, msg) {
+		return nil, fmt.Errorf("%s was rejected, the server is busy with other commands", params.Command)
+	}
+	return nil, nil
+}
+
+func (s *Server) onWorkspaceDidChangeWatchedFiles(ctx *glsp.Context, params *protocol.DidChangeWatchedFilesParams) error {
+	if len(params.Changes) == 0 {
+		return nil
+	}
+	// A checkout can change thousands of files at once, so the notification is refreshed as a whole
+	uris := make([]string, 0, len(params.Changes))
+	for _, change := range params.Changes {
+		uris = append(uris, string(change.URI))
+	}
+	s.enqueue(refreshTopic, Message{notifier: NewContextNotifier(ctx), URIs: uris})
 	return nil
 }
+
+func (s *Server) onWorkspaceDidChangeConfiguration(ctx *glsp.Context, params *protocol.DidChangeConfigurationParams) error {
+	s.enqueue(settingsTopic, Message{
+		notifier: NewContextNotifier(ctx),
+		Settings: params.Settings,
+	})
+	return nil
+}
+
+func stringArgument(arguments []any, index int) (string, bool) {
+	if index >= len(arguments) {
+		return "", false
+	}
+	value, ok := arguments[index].(string)
+	return value, ok && value != ""
+}
+
+func supportsWatchedFilesRegistration(capabilities protocol.ClientCapabilities) bool {
+	workspace := capabilities.Workspace
+	if workspace == nil || workspace.DidChangeWatchedFiles == nil || workspace.DidChangeWatchedFiles.DynamicRegistration == nil {
+		return false
+	}
+	return *workspace.DidChangeWatchedFiles.DynamicRegistration
+}
+
+func supportsCodeLensRefresh(capabilities protocol.ClientCapabilities) bool {
+	workspace := capabilities.Workspace
+	if workspace == nil || workspace.CodeLens == nil || workspace.CodeLens.RefreshSupport == nil {
+		return false
+	}
+	return *workspace.CodeLens.RefreshSupport
+}
+
+func supportsApplyEdit(capabilities protocol.ClientCapabilities) bool {
+	workspace := capabilities.Workspace
+	if workspace == nil || workspace.ApplyEdit == nil || !*workspace.ApplyEdit {
+		return false
+	}
+	return workspace.WorkspaceEdit != nil && workspace.WorkspaceEdit.DocumentChanges != nil && *workspace.WorkspaceEdit.DocumentChanges
+}
+
+func supportsConfiguration(capabilities protocol.ClientCapabilities) bool {
+	workspace := capabilities.Workspace
+	if workspace == nil || workspace.Configuration == nil {
+		return false
+	}
+	return *workspace.Configuration
+}
+
+func supportsConfigurationRegistration(capabilities protocol.ClientCapabilities) bool {
+	workspace := capabilities.Workspace
+	if workspace == nil || workspace.DidChangeConfiguration == nil || workspace.DidChangeConfiguration.DynamicRegistration == nil {
+		return false
+	}
+	return *workspace.DidChangeConfiguration.DynamicRegistration
+}
+
+// workspaceRoot returns the root of the workspace, taking the path from rootUri
+// for clients that do not send the deprecated rootPath.
+func workspaceRoot(params *protocol.InitializeParams) (string, string, error) {
+	switch {
+	case params.RootURI != nil && params.RootPath != nil:
+		return *params.RootURI, *params.RootPath, nil
+	case params.RootURI != nil:
+		u, err := url.Parse(*params.RootURI)
+		if err != nil || u.Scheme != "file" || u.Path == "" {
+			return "", "", fmt.Errorf("unsupported rootUri %q. Expected a file URI", *params.RootURI)
+		}
+		return *params.RootURI, filepath.FromSlash(u.Path), nil
+	case params.RootPath != nil:
+		return "file://" + filepath.ToSlash(*params.RootPath), *params.RootPath, nil
+	}
+	return "", "", errors.New("no workspace root. Open a folder to use the server")
+}
@@ -1,22 +1,132 @@
 package lsp
 
-// ServerOptions represents the initialization options sent by the client
+import (
+	"strings"
+	"time"
+
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+)
+
+// settingsSection is the section of workspace/configuration holding the settings of the server
+const settingsSection = "wpks"
+
+// ServerOptions represents the initialization options sent by the client,
+// and the `wpks` section of its configuration. Both use the keys of .wpks-ls.yml in camel case.
 type ServerOptions struct {
 	CheckAllOnInitialized bool
+	Settings              domain.Settings
 }
 
 func NewServerOptions() *ServerOptions {
-	return &ServerOptions{CheckAllOnInitialized: false}
+	return &ServerOptions{CheckAllOnInitialized: false, Settings: domain.NewSettings()}
 }
 
+// Apply overrides the options present in initializationOptions. Values of the wrong type are ignored.
 func (o *ServerOptions) Apply(initializationOptions any) {
 	if initializationOptions == nil {
 		return
 	}
 
-	if optionsMap, ok := initializationOptions.(map[string]any); ok {
-		if checkAll, ok := optionsMap["checkAllOnInitialized"].(bool); ok {
-			o.CheckAllOnInitialized = checkAll
+	optionsMap, ok := initializationOptions.(map[string]any)
+	if !ok {
+		return
+	}
+
+	if checkAll, ok := optionsMap["checkAllOnInitialized"].(bool); ok {
+		o.CheckAllOnInitialized = checkAll
+	}
+	// A command line such as "bundle exec packwerk", or its words
+	switch command := optionsMap["checkerCommand"].(type) {
+	case string:
+		o.Settings.CheckerCommand = strings.Fields(command)
+	case []any:
+		if words, ok := stringSlice(command); ok {
+			o.Settings.CheckerCommand = words
+		}
+	}
+	o.Settings = applySeverity(optionsMap["severity"], o.Settings)
+	if items, ok := optionsMap["overrides"].([]any); ok {
+		overrides := make([]domain.SeverityOverride, 0, len(items))
+		for _, item := range items {
+			itemMap, _ := item.(map[string]any)
+			paths, _ := itemMap["paths"].([]any)
+			patterns, ok := stringSlice(paths)
+			if !ok || len(patterns) == 0 {
+				continue
+			}
+			overrides = append(overrides, applySeverity(itemMap["severity"], domain.SeverityOverride{Paths: patterns}))
+		}
+		o.Settings.Overrides = overrides
+	}
+	if paths, ok := optionsMap["excludePaths"].([]any); ok {
+		if patterns, ok := stringSlice(paths); ok {
+			o.Settings.ExcludePaths = patterns
+		}
+	}
+	// JSON numbers are decoded as float64
+	if seconds, ok := optionsMap["timeout"].(float64); ok && seconds >= 0 {
+		o.Settings.Timeout = time.Duration(seconds * float64(time.Second))
+	}
+	if concurrency, ok := optionsMap["concurrency"].(float64); ok && concurrency >= 1 {
+		o.Settings.Concurrency = int(concurrency)
+	}
+	if size, ok := optionsMap["batchSize"].(float64); ok && size >= 1 {
+		o.Settings.BatchSize = int(size)
+	}
+	if milliseconds, ok := optionsMap["batchDelay"].(float64); ok && milliseconds >= 0 {
+		o.Settings.BatchDelay = time.Duration(milliseconds * float64(time.Millisecond))
+	}
+	if directory, ok := optionsMap["cacheDirectory"].(string); ok && directory != "" {
+		o.Settings.CacheDirectory = directory
+	}
+	if template, ok := optionsMap["messageTemplate"].(string); ok {
+		o.Settings.MessageTemplate = template
+	}
+	// "HEAD" reports only the violations of the changes not committed yet
+	if base, ok := optionsMap["diffBase"].(string); ok {
+		o.Settings.DiffBase = base
+	}
+	if name, ok := optionsMap["baselineSeverity"].(string); ok {
+		if severity, ok := domain.ParseSeverity(name); ok {
+			o.Settings.BaselineSeverity = severity
+		}
+	}
+}
+
+// applySeverity reads a severity for every violation, or one per violation kind.
+func applySeverity[T interface{ WithSeverity(string, int32) T }](value any, target T) T {
+	switch severities := value.(type) {
+	case string:
+		if severity, ok := domain.ParseSeverity(severities); ok {
+			target = target.WithSeverity("default", severity)
+		}
+	case map[string]any:
+		for kind, value := range severities {
+			name, _ := value.(string)
+			if severity, ok := domain.ParseSeverity(name); ok {
+				target = target.WithSeverity(kind, severity)
+			}
+		}
+	}
+	return target
+}
+
+// settingsOf returns the `wpks` section of the settings pushed by workspace/didChangeConfiguration.
+func settingsOf(settings any) any {
+	if settingsMap, ok := settings.(map[string]any); ok {
+		return settingsMap[settingsSection]
+	}
+	return nil
+}
+
+func stringSlice(values []any) ([]string, bool) {
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		s, ok := value.(string)
+		if !ok {
+			return nil, false
 		}
+		result = append(result, s)
 	}
+	return result, true
 }
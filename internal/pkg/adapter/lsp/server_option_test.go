@@ -1,7 +1,11 @@
 package lsp
 
 import (
+	"reflect"
 	"testing"
+	"time"
+
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
 )
 
 func TestServerOptions_Apply(t *testing.T) {
@@ -127,6 +131,130 @@ func TestServerOptions_Apply(t *testing.T) {
 	}
 }
 
+func TestServerOptions_Apply_Settings(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  any
+		expected domain.Settings
+	}{
+		{
+			name:     "defaults",
+			options:  nil,
+			expected: domain.NewSettings(),
+		},
+		{
+			name: "all settings",
+			options: map[string]any{
+				"checkerCommand":   "bundle exec packwerk",
+				"severity":         "warning",
+				"excludePaths":     []any{"spec/**", "test/**"},
+				"timeout":          float64(1.5),
+				"concurrency":      float64(4),
+				"batchSize":        float64(20),
+				"batchDelay":       float64(250),
+				"cacheDirectory":   "tmp/packwerk",
+				"messageTemplate":  "{constant} ({kind})",
+				"diffBase":         "HEAD",
+				"baselineSeverity": "information",
+			},
+			expected: domain.Settings{
+				CheckerCommand:   []string{"bundle", "exec", "packwerk"},
+				Severity:         domain.SeverityWarning,
+				ExcludePaths:     []string{"spec/**", "test/**"},
+				Timeout:          1500 * time.Millisecond,
+				Concurrency:      4,
+				BatchSize:        20,
+				BatchDelay:       250 * time.Millisecond,
+				CacheDirectory:   "tmp/packwerk",
+				MessageTemplate:  "{constant} ({kind})",
+				DiffBase:         "HEAD",
+				BaselineSeverity: domain.SeverityInfo,
+			},
+		},
+		{
+			name: "severity per violation kind",
+			options: map[string]any{
+				"severity": map[string]any{"default": "warning", "privacy": "hint", "layer": "fatal"},
+			},
+			expected: domain.NewSettings().WithSeverity("default", domain.SeverityWarning).WithSeverity("privacy", domain.SeverityHint),
+		},
+		{
+			name: "overrides",
+			options: map[string]any{
+				"severity": map[string]any{"layer": "ignore"},
+				"overrides": []any{
+					map[string]any{"paths": []any{"spec/**"}, "severity": "hint"},
+					map[string]any{"paths": []any{"packs/legacy/**"}, "severity": map[string]any{"layer": "warning"}},
+					map[string]any{"severity": "error"},
+				},
+			},
+			expected: func() domain.Settings {
+				settings := domain.NewSettings().WithSeverity("layer", domain.SeverityIgnore)
+				settings.Overrides = []domain.SeverityOverride{
+					{Paths: []string{"spec/**"}, Severity: domain.SeverityHint},
+					{Paths: []string{"packs/legacy/**"}, SeverityByKind: map[string]int32{"layer": domain.SeverityWarning}},
+				}
+				return settings
+			}(),
+		},
+		{
+			name: "checker command as words",
+			options: map[string]any{
+				"checkerCommand": []any{"docker", "compose", "exec", "-T", "web", "bin/packwerk"},
+			},
+			expected: domain.Settings{
+				CheckerCommand:   []string{"docker", "compose", "exec", "-T", "web", "bin/packwerk"},
+				Severity:         domain.SeverityError,
+				Concurrency:      1,
+				BatchSize:        10,
+				BatchDelay:       100 * time.Millisecond,
+				BaselineSeverity: domain.SeverityHint,
+			},
+		},
+		{
+			name: "invalid values keep the defaults",
+			options: map[string]any{
+				"checkerCommand":   []any{"bin/packwerk", 1},
+				"severity":         "fatal",
+				"excludePaths":     "spec/**",
+				"timeout":          float64(-1),
+				"concurrency":      float64(0),
+				"batchSize":        float64(0),
+				"baselineSeverity": "fatal",
+			},
+			expected: domain.NewSettings(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := NewServerOptions()
+			options.Apply(tt.options)
+
+			if !reflect.DeepEqual(options.Settings, tt.expected) {
+				t.Errorf("Apply() Settings = %+v, expected %+v", options.Settings, tt.expected)
+			}
+		})
+	}
+}
+
+func TestServerOptions_Apply_Section(t *testing.T) {
+	// The `wpks` section is applied over the initialization options
+	options := NewServerOptions()
+	options.Apply(map[string]any{"checkAllOnInitialized": true, "severity": "hint"})
+	options.Apply(settingsOf(map[string]any{
+		"wpks":  map[string]any{"severity": "info"},
+		"other": map[string]any{"severity": "error"},
+	}))
+
+	if !options.CheckAllOnInitialized || options.Settings.Severity != domain.SeverityInfo {
+		t.Errorf("unexpected options: %+v", options)
+	}
+	if settingsOf("wpks") != nil {
+		t.Error("expected no section when the settings are not an object")
+	}
+}
+
 func TestNewServerOptions(t *testing.T) {
 	options := NewServerOptions()
 
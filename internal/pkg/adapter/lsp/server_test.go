@@ -0,0 +1,201 @@
+package lsp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/port/in"
+	"github.com/sourcegraph/jsonrpc2"
+	protocol "github.com/tliron/glsp/protocol_3_16"
+)
+
+func TestServer_WatchedFilesFlood(t *testing.T) {
+	listener, _ := serveListener(t, "tcp://127.0.0.1:0", newTestUsecases)
+	conn := dialTCP(t, listener)
+	initialize(t, conn)
+
+	// A large checkout changes more files than the refresh queue holds
+	var params protocol.DidChangeWatchedFilesParams
+	for i := range 20000 {
+		params.Changes = append(params.Changes, protocol.FileEvent{
+			URI:  protocol.DocumentUri(fmt.Sprintf("file:///root/packs/pack%d/package.yml", i)),
+			Type: protocol.FileChangeTypeChanged,
+		})
+	}
+	if err := conn.Notify(context.Background(), protocol.MethodWorkspaceDidChangeWatchedFiles, params); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assertServes(t, conn)
+}
+
+// blockingChecker holds the command worker in ClearCache until it is released.
+type blockingChecker struct {
+	in.ManageChecker
+	release chan struct{}
+}
+
+func (c *blockingChecker) ClearCache() error {
+	<-c.release
+	return nil
+}
+
+func TestServer_CommandFlood(t *testing.T) {
+	checker := &blockingChecker{release: make(chan struct{})}
+	listener, _ := serveListener(t, "tcp://127.0.0.1:0", func() Usecases {
+		usecases := newTestUsecases()
+		checker.ManageChecker = usecases.ManageChecker
+		usecases.ManageChecker = checker
+		return usecases
+	})
+	conn := dialTCP(t, listener)
+	initialize(t, conn)
+
+	// More commands than the command queue holds while the first one is running
+	rejected := 0
+	for range 15 {
+		params := protocol.ExecuteCommandParams{Command: CommandClearCache}
+		err := conn.Call(context.Background(), protocol.MethodWorkspaceExecuteCommand, params, nil)
+		var rpcError *jsonrpc2.Error
+		switch {
+		case err == nil:
+		case errors.As(err, &rpcError) && strings.Contains(rpcError.Message, "server is busy"):
+			rejected++
+		default:
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if rejected == 0 {
+		t.Error("want the commands beyond the queue rejected")
+	}
+
+	// Shutting down waits for the running command
+	close(checker.release)
+	assertServes(t, conn)
+}
+
+// recordingSettings keeps the settings last applied.
+type recordingSettings struct {
+	in.ConfigureSettings
+	mu       sync.Mutex
+	settings domain.Settings
+}
+
+func (c *recordingSettings) Configure(settings domain.Settings) error {
+	c.mu.Lock()
+	c.settings = settings
+	c.mu.Unlock()
+	return c.ConfigureSettings.Configure(settings)
+}
+
+func TestServer_RemoteSettings(t *testing.T) {
+	settings := &recordingSettings{}
+	listener, _ := serveListener(t, "tcp://127.0.0.1:0", func() Usecases {
+		usecases := newTestUsecases()
+		settings.ConfigureSettings = usecases.ConfigureSettings
+		usecases.ConfigureSettings = settings
+		return usecases
+	})
+	conn := dialTCP(t, listener)
+
+	root := t.TempDir()
+	rootUri := protocol.DocumentUri("file://" + root)
+	params := protocol.InitializeParams{
+		RootURI:               &rootUri,
+		RootPath:              &root,
+		InitializationOptions: map[string]any{"checkerCommand": "sh -c 'touch pwned'", "cacheDirectory": ".", "timeout": 5},
+	}
+	if err := conn.Call(context.Background(), "initialize", params, nil); err != nil {
+		t.Fatalf("want initialize to succeed, got %v", err)
+	}
+
+	settings.mu.Lock()
+	got := settings.settings
+	settings.mu.Unlock()
+	if len(got.CheckerCommand) != 0 {
+		t.Errorf("want the checker command of a network client ignored, got %q", got.CheckerCommand)
+	}
+	if got.CacheDirectory != "" {
+		t.Errorf("want the cache directory of a network client ignored, got %q", got.CacheDirectory)
+	}
+	if got.Timeout != 5*time.Second {
+		t.Errorf("want the other options applied, got timeout %v", got.Timeout)
+	}
+	assertServes(t, conn)
+}
+
+func TestServer_RemoteRoot(t *testing.T) {
+	listener, _ := serveListener(t, "tcp://127.0.0.1:0", newTestUsecases)
+
+	// Clients only sending rootUri are served
+	conn := dialTCP(t, listener)
+	rootUri := protocol.DocumentUri("file://" + filepath.ToSlash(t.TempDir()))
+	if err := conn.Call(context.Background(), "initialize", protocol.InitializeParams{RootURI: &rootUri}, nil); err != nil {
+		t.Fatalf("want initialize with rootUri only to succeed, got %v", err)
+	}
+	assertServes(t, conn)
+
+	// The workspace must be inside the directory the server was started for
+	conn = dialTCP(t, listener)
+	outside := "/"
+	outsideUri := protocol.DocumentUri("file:///")
+	err := conn.Call(context.Background(), "initialize", protocol.InitializeParams{RootURI: &outsideUri, RootPath: &outside}, nil)
+	if err == nil || !strings.Contains(err.Error(), "outside") {
+		t.Errorf("want a workspace outside of the root rejected, got %v", err)
+	}
+
+	conn = dialTCP(t, listener)
+	if err := conn.Call(context.Background(), "initialize", protocol.InitializeParams{}, nil); err == nil {
+		t.Error("want initialize without a root rejected")
+	}
+}
+
+func TestServer_ShutdownWhileWaitingForClient(t *testing.T) {
+	listener, _ := serveListener(t, "tcp://127.0.0.1:0", newTestUsecases)
+	socket, err := net.Dial("tcp", listener.Addr().String())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The client answers workspace/configuration only after its shutdown request returned
+	requested := make(chan struct{})
+	shutDown := make(chan struct{})
+	client := jsonrpc2.AsyncHandler(jsonrpc2.HandlerWithError(func(ctx context.Context, conn *jsonrpc2.Conn, request *jsonrpc2.Request) (any, error) {
+		if request.Method == protocol.ServerWorkspaceConfiguration {
+			close(requested)
+			<-shutDown
+		}
+		return nil, nil
+	}))
+	conn := jsonrpc2.NewConn(context.Background(), jsonrpc2.NewBufferedStream(socket, jsonrpc2.VSCodeObjectCodec{}), client)
+	defer conn.Close()
+
+	root := t.TempDir()
+	params := map[string]any{
+		"rootUri":      "file://" + root,
+		"rootPath":     root,
+		"capabilities": map[string]any{"workspace": map[string]any{"configuration": true}},
+	}
+	if err := conn.Call(context.Background(), "initialize", params, nil); err != nil {
+		t.Fatalf("want initialize to succeed, got %v", err)
+	}
+	if err := conn.Notify(context.Background(), protocol.MethodWorkspaceDidChangeConfiguration, protocol.DidChangeConfigurationParams{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	<-requested
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	if err := conn.Call(ctx, "shutdown", nil, nil); err != nil {
+		t.Errorf("want shutdown to return while a job waits for the client, got %v", err)
+	}
+	close(shutDown)
+}
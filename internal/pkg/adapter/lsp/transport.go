@@ -0,0 +1,209 @@
+package lsp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/sourcegraph/jsonrpc2"
+	wsjsonrpc2 "github.com/sourcegraph/jsonrpc2/websocket"
+	"github.com/tliron/glsp"
+)
+
+// Listener accepts clients over TCP or WebSocket, e.g. an editor on the host connecting to
+// a server in a dev container. Each connection is served by a Server of its own, so clients
+// do not share their workspace, settings or checks.
+type Listener struct {
+	scheme      string // "tcp" or "ws"
+	path        string // the path WebSocket clients connect to
+	rootPath    string // the directory the workspaces of the clients must be inside of
+	listener    net.Listener
+	newUsecases func() Usecases
+	connections atomic.Int64
+}
+
+// NewListener binds the address, e.g. "tcp://127.0.0.1:7658" or "ws://127.0.0.1:7658/lsp".
+// Clients are not authenticated, so hosts other than loopback are refused unless allowRemote is set,
+// and clients can only open workspaces inside rootPath. newUsecases is called for every connection.
+func NewListener(address string, allowRemote bool, rootPath string, newUsecases func() Usecases) (*Listener, error) {
+	u, err := url.Parse(address)
+	if err != nil || (u.Scheme != "tcp" && u.Scheme != "ws") || u.Host == "" {
+		return nil, fmt.Errorf("invalid address %q. Expected tcp://host:port or ws://host:port", address)
+	}
+	if !allowRemote && !isLoopback(u.Hostname()) {
+		return nil, fmt.Errorf("refusing to listen on %q: clients are not authenticated. Use a loopback host such as 127.0.0.1, or --allow-remote", u.Host)
+	}
+	path := u.Path
+	if path == "" {
+		path = "/"
+	}
+	rootPath, err = filepath.Abs(rootPath)
+	if err != nil {
+		return nil, err
+	}
+
+	listener, err := net.Listen("tcp", u.Host)
+	if err != nil {
+		return nil, err
+	}
+	return &Listener{scheme: u.Scheme, path: path, rootPath: rootPath, listener: listener, newUsecases: newUsecases}, nil
+}
+
+// isLoopback reports whether the host only accepts connections from the same machine.
+// An empty host listens on every interface.
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// isInside reports whether the path is the root or below it, once symbolic links are followed.
+func isInside(root string, path string) bool {
+	root, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		return false
+	}
+	path, err = filepath.EvalSymlinks(path)
+	if err != nil {
+		return false
+	}
+	relative, err := filepath.Rel(root, path)
+	return err == nil && filepath.IsLocal(relative)
+}
+
+// Addr returns the address the clients connect to, with the port chosen when it was 0.
+func (l *Listener) Addr() net.Addr {
+	return l.listener.Addr()
+}
+
+// Serve accepts connections until the context is done. Open connections are closed then.
+func (l *Listener) Serve(ctx context.Context) error {
+	stop := context.AfterFunc(ctx, func() { l.listener.Close() })
+	defer stop()
+
+	var err error
+	if l.scheme == "ws" {
+		err = l.serveWebSocket(ctx)
+	} else {
+		err = l.serveTCP(ctx)
+	}
+	if ctx.Err() != nil || errors.Is(err, net.ErrClosed) {
+		return nil
+	}
+	return err
+}
+
+// Close stops accepting connections.
+func (l *Listener) Close() error {
+	return l.listener.Close()
+}
+
+func (l *Listener) serveTCP(ctx context.Context) error {
+	for {
+		conn, err := l.listener.Accept()
+		if err != nil {
+			return err
+		}
+		go l.serve(ctx, jsonrpc2.NewBufferedStream(conn, jsonrpc2.VSCodeObjectCodec{}))
+	}
+}
+
+func (l *Listener) serveWebSocket(ctx context.Context) error {
+	// The default origin check turns away web pages other than the server's own,
+	// while editors, which send no origin, can connect
+	upgrader := websocket.Upgrader{}
+	mux := http.NewServeMux()
+	mux.HandleFunc(l.path, func(w http.ResponseWriter, r *http.Request) {
+		socket, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			// The upgrader has already replied with the error
+			return
+		}
+		l.serve(ctx, wsjsonrpc2.NewObjectStream(socket))
+	})
+
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	return server.Serve(l.listener)
+}
+
+// serve runs a new Server until the client disconnects or the context is done.
+func (l *Listener) serve(ctx context.Context, stream jsonrpc2.ObjectStream) {
+	id := l.connections.Add(1)
+	log.Printf("%s: connection #%d opened", serverName, id)
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("%s: connection #%d failed: %v", serverName, id, r)
+		}
+	}()
+
+	server := NewServer(l.newUsecases())
+	server.remoteRoot = l.rootPath
+	conn := jsonrpc2.NewConn(ctx, stream, newConnectionHandler(server.newHandler()))
+	select {
+	case <-conn.DisconnectNotify():
+	case <-ctx.Done():
+		conn.Close()
+	}
+	server.disconnect()
+
+	log.Printf("%s: connection #%d closed", serverName, id)
+}
+
+// newConnectionHandler passes the requests of a connection to the handler,
+// as glsp does for the single connection of stdio.
+// A panic fails the request rather than every connection of the process.
+func newConnectionHandler(handler glsp.Handler) jsonrpc2.Handler {
+	return jsonrpc2.HandlerWithError(func(ctx context.Context, conn *jsonrpc2.Conn, request *jsonrpc2.Request) (result any, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				log.Printf("%s: %s panicked: %v", serverName, request.Method, r)
+				result, err = nil, &jsonrpc2.Error{Code: jsonrpc2.CodeInternalError, Message: fmt.Sprintf("internal error: %v", r)}
+			}
+		}()
+
+		glspContext := glsp.Context{
+			Method: request.Method,
+			Notify: func(method string, params any) {
+				if err := conn.Notify(ctx, method, params); err != nil {
+					log.Printf("%s: failed to send %s: %v", serverName, method, err)
+				}
+			},
+			Call: func(method string, params any, result any) {
+				if err := conn.Call(ctx, method, params, result); err != nil {
+					log.Printf("%s: failed to call %s: %v", serverName, method, err)
+				}
+			},
+		}
+		if request.Params != nil {
+			glspContext.Params = *request.Params
+		}
+
+		result, validMethod, validParams, err := handler.Handle(&glspContext)
+		if request.Method == "exit" {
+			return nil, conn.Close()
+		}
+		switch {
+		case !validMethod:
+			return nil, &jsonrpc2.Error{Code: jsonrpc2.CodeMethodNotFound, Message: fmt.Sprintf("method not supported: %s", request.Method)}
+		case !validParams:
+			message := ""
+			if err != nil {
+				message = err.Error()
+			}
+			return nil, &jsonrpc2.Error{Code: jsonrpc2.CodeInvalidParams, Message: message}
+		case err != nil:
+			return nil, &jsonrpc2.Error{Code: jsonrpc2.CodeInvalidRequest, Message: err.Error()}
+		}
+		return result, nil
+	})
+}
@@ -0,0 +1,205 @@
+package lsp
+
+import (
+	"context"
+	"errors"
+	"net"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/adapter/filesystem"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/adapter/inmemory"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/adapter/packwerk"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/adapter/packwerk/config"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/usecase"
+	"github.com/sourcegraph/jsonrpc2"
+	wsjsonrpc2 "github.com/sourcegraph/jsonrpc2/websocket"
+	"github.com/tliron/glsp"
+	protocol "github.com/tliron/glsp/protocol_3_16"
+)
+
+// newTestUsecases provides what initialize needs, with repositories of their own.
+func newTestUsecases() Usecases {
+	workspaceRepository := inmemory.NewWorkspaceRepository()
+	packageRepository := inmemory.NewPackageRepository()
+	settingsRepository := inmemory.NewSettingsRepository()
+	violationRepository := inmemory.NewViolationRepository()
+	runner := packwerk.NewRunnerWithDefaultCheckers()
+	fileSystem := filesystem.NewFileSystem()
+	return Usecases{
+		CreateWorkspace:   usecase.NewCreateWorkspace(workspaceRepository),
+		LoadPackages:      usecase.NewLoadPackages(workspaceRepository, packageRepository, config.NewReader()),
+		ConfigureSettings: usecase.NewConfigureSettings(workspaceRepository, settingsRepository, runner, fileSystem, config.NewYamlParser()),
+		ManageChecker:     usecase.NewManageChecker(workspaceRepository, packageRepository, settingsRepository, runner, fileSystem),
+		ManageBaseline:    usecase.NewManageBaseline(workspaceRepository, violationRepository, settingsRepository, fileSystem, runner),
+	}
+}
+
+func dialTCP(t *testing.T, listener *Listener) *jsonrpc2.Conn {
+	t.Helper()
+	socket, err := net.Dial("tcp", listener.Addr().String())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	conn := jsonrpc2.NewConn(context.Background(), jsonrpc2.NewBufferedStream(socket, jsonrpc2.VSCodeObjectCodec{}), ignoreRequests())
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// ignoreRequests answers the notifications and requests of the server to the client.
+func ignoreRequests() jsonrpc2.Handler {
+	return jsonrpc2.HandlerWithError(func(context.Context, *jsonrpc2.Conn, *jsonrpc2.Request) (any, error) {
+		return nil, nil
+	})
+}
+
+func initialize(t *testing.T, conn *jsonrpc2.Conn) {
+	t.Helper()
+	root := t.TempDir()
+	rootUri := protocol.DocumentUri("file://" + root)
+	params := protocol.InitializeParams{RootURI: &rootUri, RootPath: &root}
+	var result protocol.InitializeResult
+	if err := conn.Call(context.Background(), "initialize", params, &result); err != nil {
+		t.Fatalf("want initialize to succeed, got %v", err)
+	}
+	if result.ServerInfo == nil || result.ServerInfo.Name != serverName {
+		t.Errorf("unexpected initialize result: %+v", result)
+	}
+}
+
+// serveListener serves the listener until the test ends, counting the servers created.
+func serveListener(t *testing.T, address string, newUsecases func() Usecases) (*Listener, *atomic.Int32) {
+	t.Helper()
+	servers := &atomic.Int32{}
+	// The workspaces of initialize are temporary directories of the test
+	listener, err := NewListener(address, false, filepath.Dir(t.TempDir()), func() Usecases {
+		servers.Add(1)
+		return newUsecases()
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- listener.Serve(ctx) }()
+	t.Cleanup(func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("want Serve to stop cleanly, got %v", err)
+		}
+	})
+	return listener, servers
+}
+
+// assertServes checks that the connection is answered by an initialized language server.
+func assertServes(t *testing.T, conn *jsonrpc2.Conn) {
+	t.Helper()
+	var rpcError *jsonrpc2.Error
+	if err := conn.Call(context.Background(), "wpks/unknown", nil, nil); !errors.As(err, &rpcError) || rpcError.Code != jsonrpc2.CodeMethodNotFound {
+		t.Errorf("want an unknown method rejected, got %v", err)
+	}
+	if err := conn.Call(context.Background(), "shutdown", nil, nil); err != nil {
+		t.Errorf("want shutdown to succeed, got %v", err)
+	}
+}
+
+func TestListener_TCP(t *testing.T) {
+	listener, servers := serveListener(t, "tcp://127.0.0.1:0", newTestUsecases)
+
+	first := dialTCP(t, listener)
+	initialize(t, first)
+	assertServes(t, first)
+
+	// The second client does not share the state of the first one
+	second := dialTCP(t, listener)
+	var rpcError *jsonrpc2.Error
+	if err := second.Call(context.Background(), "shutdown", nil, nil); !errors.As(err, &rpcError) || rpcError.Code != jsonrpc2.CodeInvalidRequest {
+		t.Errorf("want the second client not initialized yet, got %v", err)
+	}
+	initialize(t, second)
+	assertServes(t, second)
+
+	if got := servers.Load(); got != 2 {
+		t.Errorf("want a server per connection, got %d", got)
+	}
+}
+
+func TestListener_WebSocket(t *testing.T) {
+	listener, servers := serveListener(t, "ws://127.0.0.1:0/lsp", newTestUsecases)
+
+	socket, _, err := websocket.DefaultDialer.Dial("ws://"+listener.Addr().String()+"/lsp", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	conn := jsonrpc2.NewConn(context.Background(), wsjsonrpc2.NewObjectStream(socket), ignoreRequests())
+	defer conn.Close()
+	initialize(t, conn)
+	assertServes(t, conn)
+	if got := servers.Load(); got != 1 {
+		t.Errorf("want a server for the connection, got %d", got)
+	}
+}
+
+// panicHandler panics on every request but exit.
+type panicHandler struct{}
+
+func (panicHandler) Handle(ctx *glsp.Context) (any, bool, bool, error) {
+	if ctx.Method == "exit" {
+		return nil, true, true, nil
+	}
+	panic("broken handler")
+}
+
+func TestNewConnectionHandler_Panic(t *testing.T) {
+	serverSide, clientSide := net.Pipe()
+	server := jsonrpc2.NewConn(context.Background(), jsonrpc2.NewBufferedStream(serverSide, jsonrpc2.VSCodeObjectCodec{}), newConnectionHandler(panicHandler{}))
+	defer server.Close()
+	client := jsonrpc2.NewConn(context.Background(), jsonrpc2.NewBufferedStream(clientSide, jsonrpc2.VSCodeObjectCodec{}), ignoreRequests())
+	defer client.Close()
+
+	// The connection keeps answering after a request panicked
+	for range 2 {
+		var rpcError *jsonrpc2.Error
+		if err := client.Call(context.Background(), "shutdown", nil, nil); !errors.As(err, &rpcError) || rpcError.Code != jsonrpc2.CodeInternalError {
+			t.Errorf("want an internal error, got %v", err)
+		}
+	}
+}
+
+func TestNewListener_InvalidAddress(t *testing.T) {
+	for _, address := range []string{"127.0.0.1:7658", "http://127.0.0.1:7658", "tcp://", "stdio"} {
+		if _, err := NewListener(address, false, t.TempDir(), newTestUsecases); err == nil {
+			t.Errorf("want an error for %q", address)
+		}
+	}
+}
+
+func TestNewListener_Remote(t *testing.T) {
+	for _, address := range []string{"tcp://0.0.0.0:0", "ws://:0/lsp", "tcp://192.0.2.1:0"} {
+		if _, err := NewListener(address, false, t.TempDir(), newTestUsecases); err == nil || !strings.Contains(err.Error(), "--allow-remote") {
+			t.Errorf("want %q refused without allowRemote, got %v", address, err)
+		}
+	}
+
+	for _, address := range []string{"tcp://localhost:0", "tcp://[::1]:0"} {
+		listener, err := NewListener(address, false, t.TempDir(), newTestUsecases)
+		if err != nil {
+			// ::1 is missing on hosts without IPv6, but loopback hosts are never refused
+			if strings.Contains(err.Error(), "--allow-remote") {
+				t.Errorf("want %q accepted, got %v", address, err)
+			}
+			continue
+		}
+		listener.Close()
+	}
+
+	listener, err := NewListener("tcp://0.0.0.0:0", true, t.TempDir(), newTestUsecases)
+	if err != nil {
+		t.Fatalf("want allowRemote to listen on every interface, got %v", err)
+	}
+	listener.Close()
+}
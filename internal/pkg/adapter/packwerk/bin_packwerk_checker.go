@@ -39,8 +39,7 @@ func (c *BinPackwerkChecker) RunCheck(context context.Context, rootPath string,
 
 	cmd := exec.CommandContext(context, packwerkPath, args...)
 	cmd.Dir = rootPath
-	out, _ := cmd.Output()
-	return NewPackwerkOutput(string(out)).Parse(), nil
+	return runCheckCommand(context, cmd)
 }
 
 func (c *BinPackwerkChecker) RunCheckAll(context context.Context, rootPath string) ([]domain.Violation, error) {
@@ -50,8 +49,17 @@ func (c *BinPackwerkChecker) RunCheckAll(context context.Context, rootPath strin
 	packwerkPath := filepath.Join(rootPath, "bin", "packwerk")
 	cmd := exec.CommandContext(context, packwerkPath, "check", "--offenses-formatter=default")
 	cmd.Dir = rootPath
-	out, _ := cmd.Output()
-	return NewPackwerkOutput(string(out)).Parse(), nil
+	return runCheckCommand(context, cmd)
+}
+
+func (c *BinPackwerkChecker) RunUpdateTodo(context context.Context, rootPath string) error {
+	if !c.IsAvailable(rootPath) {
+		return CommandNotFoundError{"bin/packwerk"}
+	}
+	packwerkPath := filepath.Join(rootPath, "bin", "packwerk")
+	cmd := exec.CommandContext(context, packwerkPath, "update-todo")
+	cmd.Dir = rootPath
+	return runUpdateTodo(context, cmd)
 }
 
 var _ CheckerCommand = &BinPackwerkChecker{}
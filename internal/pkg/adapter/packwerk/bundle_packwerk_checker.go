@@ -41,8 +41,7 @@ func (c *BundlePackwerkChecker) RunCheck(context context.Context, rootPath strin
 
 	cmd := exec.CommandContext(context, "bundle", args...)
 	cmd.Dir = rootPath
-	out, _ := cmd.Output()
-	return NewPackwerkOutput(string(out)).Parse(), nil
+	return runCheckCommand(context, cmd)
 }
 
 func (c *BundlePackwerkChecker) RunCheckAll(context context.Context, rootPath string) ([]domain.Violation, error) {
@@ -51,8 +50,16 @@ func (c *BundlePackwerkChecker) RunCheckAll(context context.Context, rootPath st
 	}
 	cmd := exec.CommandContext(context, "bundle", "exec", "packwerk", "check", "--offenses-formatter=default")
 	cmd.Dir = rootPath
-	out, _ := cmd.Output()
-	return NewPackwerkOutput(string(out)).Parse(), nil
+	return runCheckCommand(context, cmd)
+}
+
+func (c *BundlePackwerkChecker) RunUpdateTodo(context context.Context, rootPath string) error {
+	if !c.IsAvailable(rootPath) {
+		return CommandNotFoundError{"bundle"}
+	}
+	cmd := exec.CommandContext(context, "bundle", "exec", "packwerk", "update-todo")
+	cmd.Dir = rootPath
+	return runUpdateTodo(context, cmd)
 }
 
 var _ CheckerCommand = &BundlePackwerkChecker{}
@@ -0,0 +1,288 @@
+package config
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/port/out"
+	"gopkg.in/yaml.v3"
+)
+
+// Reader reads packwerk.yml and every package.yml below the workspace root.
+type Reader struct{}
+
+func NewReader() *Reader {
+	return &Reader{}
+}
+
+// ReadConfig parses packwerk.yml. When the file does not exist packwerk's defaults are returned.
+func (r *Reader) ReadConfig(rootPath string) (*domain.PackwerkConfig, error) {
+	config := domain.NewPackwerkConfig()
+
+	data, err := os.ReadFile(filepath.Join(rootPath, domain.PackwerkConfigFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return config, nil
+		}
+		return nil, err
+	}
+
+	return ParsePackwerkConfig(data)
+}
+
+// ReadPackages finds every package.yml matching package_paths and not excluded, and parses it.
+// The root pack is always present, even without a package.yml at the root.
+func (r *Reader) ReadPackages(rootPath string, config *domain.PackwerkConfig) ([]*domain.Package, error) {
+	patterns := make([]string, 0, len(config.PackagePaths))
+	for _, packagePath := range config.PackagePaths {
+		patterns = append(patterns, path.Join(packagePath, domain.PackageConfigFile))
+	}
+	globs, err := domain.CompileGlobSet(patterns)
+	if err != nil {
+		return nil, err
+	}
+	excludes, err := domain.CompileGlobSet(config.Exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	var packages []*domain.Package
+	hasRoot := false
+
+	err = filepath.WalkDir(rootPath, func(fullPath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if fullPath == rootPath {
+			return nil
+		}
+		relPath, err := filepath.Rel(rootPath, fullPath)
+		if err != nil {
+			return err
+		}
+		relPath = filepath.ToSlash(relPath)
+
+		if entry.IsDir() {
+			// Ruby's Dir.glob does not descend into hidden directories either
+			if strings.HasPrefix(entry.Name(), ".") {
+				return filepath.SkipDir
+			}
+			// Directories whose contents are excluded, like vendor/**/*, can be large
+			if excludes.Match(relPath + "/") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if entry.Name() != domain.PackageConfigFile {
+			return nil
+		}
+		if !globs.Match(relPath) || excludes.Match(relPath) {
+			return nil
+		}
+
+		data, err := os.ReadFile(fullPath)
+		if err != nil {
+			return err
+		}
+		pkg, err := ParsePackage(path.Dir(relPath), data)
+		if err != nil {
+			return fmt.Errorf("%s: %w", relPath, err)
+		}
+		if pkg.IsRoot() {
+			hasRoot = true
+		}
+		packages = append(packages, pkg)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !hasRoot {
+		packages = append(packages, domain.NewPackage(domain.RootPackageName))
+	}
+
+	sort.Slice(packages, func(i, j int) bool {
+		return packages[i].Name < packages[j].Name
+	})
+
+	return packages, nil
+}
+
+// ReadPublicFiles lists the Ruby files in the public folder of the pack,
+// relative to the workspace root. A pack without a public folder has none.
+func (r *Reader) ReadPublicFiles(rootPath string, pkg *domain.Package) ([]string, error) {
+	publicPath := filepath.Join(rootPath, filepath.FromSlash(pkg.PublicDirectory()))
+	if _, err := os.Stat(publicPath); err != nil {
+		if os.IsNotExist(err) {
+			return []string{}, nil
+		}
+		return nil, err
+	}
+
+	files := []string{}
+	err := filepath.WalkDir(publicPath, func(fullPath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".rb" {
+			return nil
+		}
+		relPath, err := filepath.Rel(rootPath, fullPath)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(relPath))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+// ReadPackageNode parses the package.yml of the pack keeping the position of every node.
+// A pack without a package.yml yields an empty mapping.
+func (r *Reader) ReadPackageNode(rootPath string, pkg *domain.Package) (*domain.YamlNode, error) {
+	data, err := os.ReadFile(filepath.Join(rootPath, filepath.FromSlash(pkg.ConfigPath())))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &domain.YamlNode{Kind: domain.YamlMapping}, nil
+		}
+		return nil, err
+	}
+
+	root, err := NewYamlParser().Parse(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", pkg.ConfigPath(), err)
+	}
+	return root, nil
+}
+
+// ReadPackageTodo lists the entries of the package_todo.yml of the pack.
+// A pack without a package_todo.yml has none.
+func (r *Reader) ReadPackageTodo(rootPath string, pkg *domain.Package) ([]domain.TodoEntry, error) {
+	data, err := os.ReadFile(filepath.Join(rootPath, filepath.FromSlash(pkg.TodoPath())))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []domain.TodoEntry{}, nil
+		}
+		return nil, err
+	}
+
+	root, err := NewYamlParser().Parse(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", pkg.TodoPath(), err)
+	}
+	return domain.NewTodoEntries(root), nil
+}
+
+// ParsePackwerkConfig parses the content of packwerk.yml on top of packwerk's defaults.
+func ParsePackwerkConfig(data []byte) (*domain.PackwerkConfig, error) {
+	config := domain.NewPackwerkConfig()
+
+	var raw map[string]any
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	if v, ok := raw["include"]; ok {
+		config.Include = toStringSlice(v)
+	}
+	if v, ok := raw["exclude"]; ok {
+		config.Exclude = toStringSlice(v)
+	}
+	if v, ok := raw["package_paths"]; ok {
+		config.PackagePaths = toStringSlice(v)
+	}
+	if v, ok := raw["cache"].(bool); ok {
+		config.Cache = v
+	}
+	if v, ok := raw["cache_directory"].(string); ok {
+		config.CacheDirectory = v
+	}
+	if v, ok := raw["layers"]; ok {
+		config.Layers = toStringSlice(v)
+	} else if v, ok := raw["architecture_layers"]; ok {
+		config.Layers = toStringSlice(v)
+	}
+
+	return config, nil
+}
+
+// ParsePackage parses the content of a package.yml for the pack with the given name.
+func ParsePackage(name string, data []byte) (*domain.Package, error) {
+	pkg := domain.NewPackage(name)
+
+	var raw map[string]any
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	pkg.Dependencies = toStringSlice(raw["dependencies"])
+	pkg.VisibleTo = toStringSlice(raw["visible_to"])
+	pkg.EnforceDependencies = toEnforcement(raw["enforce_dependencies"])
+	pkg.EnforcePrivacy = toEnforcement(raw["enforce_privacy"])
+	pkg.EnforceVisibility = toEnforcement(raw["enforce_visibility"])
+	if v, ok := raw["enforce_layers"]; ok {
+		pkg.EnforceLayers = toEnforcement(v)
+	} else {
+		pkg.EnforceLayers = toEnforcement(raw["enforce_architecture"])
+	}
+	if v, ok := raw["public_path"].(string); ok {
+		pkg.PublicPath = v
+	}
+	if v, ok := raw["layer"].(string); ok {
+		pkg.Layer = v
+	}
+	if v, ok := raw["metadata"].(map[string]any); ok {
+		pkg.Metadata = v
+	}
+	if v, ok := raw["owner"].(string); ok {
+		pkg.Owner = v
+	} else if v, ok := pkg.Metadata["owner"].(string); ok {
+		pkg.Owner = v
+	}
+
+	return pkg, nil
+}
+
+func toStringSlice(v any) []string {
+	switch value := v.(type) {
+	case string:
+		return []string{value}
+	case []any:
+		result := make([]string, 0, len(value))
+		for _, item := range value {
+			if s, ok := item.(string); ok {
+				result = append(result, s)
+			}
+		}
+		return result
+	}
+	return nil
+}
+
+func toEnforcement(v any) domain.Enforcement {
+	switch value := v.(type) {
+	case bool:
+		if value {
+			return domain.EnforcementEnabled
+		}
+		return domain.EnforcementDisabled
+	case string:
+		return domain.Enforcement(value)
+	case nil:
+		return domain.EnforcementUnset
+	}
+	return domain.Enforcement(fmt.Sprint(v))
+}
+
+var _ out.PackwerkConfigReader = (*Reader)(nil)
@@ -0,0 +1,258 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+)
+
+const testProjectPath = "./testdata/project"
+
+func TestReader_ReadConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		rootPath string
+		want     *domain.PackwerkConfig
+	}{
+		{
+			name:     "packwerk.yml present",
+			rootPath: testProjectPath,
+			want: &domain.PackwerkConfig{
+				Include:        []string{"**/*.{rb,rake,erb}"},
+				Exclude:        []string{"{bin,node_modules,script,tmp,vendor}/**/*"},
+				PackagePaths:   []string{".", "packs/*"},
+				Cache:          false,
+				CacheDirectory: "tmp/cache/custom",
+				Layers:         []string{"product", "utility"},
+			},
+		},
+		{
+			name:     "packwerk.yml missing falls back to defaults",
+			rootPath: t.TempDir(),
+			want:     domain.NewPackwerkConfig(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewReader().ReadConfig(tt.rootPath)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("want %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestReader_ReadPackages(t *testing.T) {
+	reader := NewReader()
+	config, err := reader.ReadConfig(testProjectPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	packages, err := reader.ReadPackages(testProjectPath, config)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []*domain.Package{
+		{
+			Name:                ".",
+			EnforceDependencies: domain.EnforcementEnabled,
+		},
+		{
+			Name:                "packs/books",
+			EnforceDependencies: domain.EnforcementStrict,
+			EnforcePrivacy:      domain.EnforcementEnabled,
+			PublicPath:          "app/api",
+			Layer:               "utility",
+			Owner:               "Library",
+			Metadata:            map[string]any{"owner": "Library"},
+		},
+		{
+			Name:                "packs/users",
+			Dependencies:        []string{"packs/books"},
+			EnforceDependencies: domain.EnforcementEnabled,
+			EnforcePrivacy:      domain.EnforcementDisabled,
+			EnforceVisibility:   domain.EnforcementEnabled,
+			VisibleTo:           []string{"."},
+			Layer:               "product",
+			Owner:               "Accounts",
+		},
+	}
+
+	if !reflect.DeepEqual(packages, want) {
+		for _, p := range packages {
+			t.Logf("got %+v", p)
+		}
+		t.Errorf("unexpected packages")
+	}
+}
+
+func TestReader_ReadPackages_AddsRootPackage(t *testing.T) {
+	packages, err := NewReader().ReadPackages(t.TempDir(), domain.NewPackwerkConfig())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(packages) != 1 || !packages[0].IsRoot() {
+		t.Errorf("expected only the root package, got %+v", packages)
+	}
+}
+
+func TestReader_ReadPackages_Exclude(t *testing.T) {
+	rootPath := t.TempDir()
+	for _, name := range []string{"packs/books", "packs/legacy", "packs/legacy/archive", "packs/old", "vendor/bundle/gems/engine"} {
+		dir := filepath.Join(rootPath, filepath.FromSlash(name))
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatalf("failed to create %s: %v", name, err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, domain.PackageConfigFile), []byte("enforce_dependencies: true\n"), 0o644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	config := domain.NewPackwerkConfig()
+	config.Exclude = append(config.Exclude, "packs/legacy/**/*", "packs/old/package.yml")
+	packages, err := NewReader().ReadPackages(rootPath, config)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	names := make([]string, 0, len(packages))
+	for _, pkg := range packages {
+		names = append(names, pkg.Name)
+	}
+	if want := []string{".", "packs/books"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("want packages %v, got %v", want, names)
+	}
+}
+
+func TestReader_ReadPublicFiles(t *testing.T) {
+	tests := []struct {
+		name string
+		pkg  *domain.Package
+		want []string
+	}{
+		{
+			name: "custom public path",
+			pkg:  &domain.Package{Name: "packs/books", PublicPath: "app/api"},
+			want: []string{"packs/books/app/api/books/search.rb", "packs/books/app/api/books_api.rb"},
+		},
+		{
+			name: "no public folder",
+			pkg:  domain.NewPackage("packs/users"),
+			want: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewReader().ReadPublicFiles(testProjectPath, tt.pkg)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("want %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestReader_ReadPackageNode(t *testing.T) {
+	root, err := NewReader().ReadPackageNode(testProjectPath, domain.NewPackage("packs/users"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	items := root.Get("dependencies").Items()
+	if len(items) != 1 || items[0].Value != "packs/books" {
+		t.Fatalf("unexpected dependencies: %+v", items)
+	}
+
+	root, err = NewReader().ReadPackageNode(testProjectPath, domain.NewPackage("packs/missing"))
+	if err != nil || root.Kind != domain.YamlMapping || len(root.Children) != 0 {
+		t.Errorf("want an empty mapping for a missing package.yml, got %+v (%v)", root, err)
+	}
+}
+
+func TestReader_ReadPackageTodo(t *testing.T) {
+	got, err := NewReader().ReadPackageTodo(testProjectPath, domain.NewPackage("packs/users"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []domain.TodoEntry{
+		{
+			ReferencedPackage: "packs/books",
+			Constant:          "::Book",
+			ViolationTypes:    []string{"dependency", "privacy"},
+			File:              "packs/users/app/models/user.rb",
+			Range:             domain.Range{Start: domain.Position{Line: 14, Character: 6}, End: domain.Position{Line: 14, Character: 36}},
+		},
+		{
+			ReferencedPackage: "packs/books",
+			Constant:          "::Book",
+			ViolationTypes:    []string{"dependency", "privacy"},
+			File:              "packs/users/app/services/checkout.rb",
+			Range:             domain.Range{Start: domain.Position{Line: 15, Character: 6}, End: domain.Position{Line: 15, Character: 42}},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %+v, got %+v", want, got)
+	}
+
+	got, err = NewReader().ReadPackageTodo(testProjectPath, domain.NewPackage("packs/books"))
+	if err != nil || len(got) != 0 {
+		t.Errorf("want no entries without package_todo.yml, got %+v (%v)", got, err)
+	}
+}
+
+func TestParsePackage(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    *domain.Package
+	}{
+		{
+			name:    "empty file",
+			content: "",
+			want:    &domain.Package{Name: "packs/a"},
+		},
+		{
+			name:    "legacy architecture flag",
+			content: "enforce_architecture: true\n",
+			want:    &domain.Package{Name: "packs/a", EnforceLayers: domain.EnforcementEnabled},
+		},
+		{
+			name:    "single dependency as a scalar",
+			content: "dependencies: packs/b\n",
+			want:    &domain.Package{Name: "packs/a", Dependencies: []string{"packs/b"}},
+		},
+		{
+			name:    "invalid enforcement is kept as written",
+			content: "enforce_privacy: sometimes\n",
+			want:    &domain.Package{Name: "packs/a", EnforcePrivacy: domain.Enforcement("sometimes")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePackage("packs/a", []byte(tt.content))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("want %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestParsePackage_InvalidYaml(t *testing.T) {
+	if _, err := ParsePackage("packs/a", []byte("dependencies: [")); err == nil {
+		t.Error("expected error for invalid yaml")
+	}
+}
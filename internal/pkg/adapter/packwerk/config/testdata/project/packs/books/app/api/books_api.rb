@@ -0,0 +1,2 @@
+module BooksApi
+end
@@ -0,0 +1,90 @@
+package config
+
+import (
+	"regexp"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/port/out"
+	"gopkg.in/yaml.v3"
+)
+
+var yamlErrorRegex = regexp.MustCompile(`^yaml: line (\d+): (.*)$`)
+
+// YamlParser parses YAML documents keeping the position of every node,
+// which is what the editor features working on package.yml need.
+type YamlParser struct{}
+
+func NewYamlParser() *YamlParser {
+	return &YamlParser{}
+}
+
+// Parse returns the root node of the document. An empty document yields an empty mapping.
+func (p *YamlParser) Parse(text string) (*domain.YamlNode, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal([]byte(text), &document); err != nil {
+		return nil, toSyntaxError(err)
+	}
+	if document.Kind != yaml.DocumentNode || len(document.Content) == 0 {
+		return &domain.YamlNode{Kind: domain.YamlMapping}, nil
+	}
+	return convertNode(document.Content[0]), nil
+}
+
+func toSyntaxError(err error) error {
+	m := yamlErrorRegex.FindStringSubmatch(err.Error())
+	if m == nil {
+		return &domain.YamlSyntaxError{Line: 0, Message: err.Error()}
+	}
+	line, _ := strconv.Atoi(m[1])
+	return &domain.YamlSyntaxError{Line: uint32(max(line-1, 0)), Message: m[2]}
+}
+
+func convertNode(node *yaml.Node) *domain.YamlNode {
+	result := &domain.YamlNode{Range: nodeRange(node)}
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		result.Kind = domain.YamlMapping
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			child := convertNode(value)
+			child.Key = key.Value
+			child.KeyRange = nodeRange(key)
+			result.Children = append(result.Children, child)
+		}
+	case yaml.SequenceNode:
+		result.Kind = domain.YamlSequence
+		for _, item := range node.Content {
+			result.Children = append(result.Children, convertNode(item))
+		}
+	case yaml.AliasNode:
+		result.Kind = domain.YamlScalar
+		result.Value = node.Value
+	default:
+		result.Kind = domain.YamlScalar
+		if node.Tag != "!!null" {
+			result.Value = node.Value
+		}
+	}
+
+	return result
+}
+
+// nodeRange converts the 1-based position of yaml.v3 into a 0-based range.
+// Only scalars get a meaningful end; collections span their start position.
+func nodeRange(node *yaml.Node) domain.Range {
+	start := domain.Position{Line: uint32(node.Line - 1), Character: uint32(node.Column - 1)}
+	end := start
+	if node.Kind == yaml.ScalarNode {
+		length := utf8.RuneCountInString(node.Value)
+		if node.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle) != 0 {
+			length += 2
+		}
+		end.Character += uint32(length)
+	}
+	return domain.Range{Start: start, End: end}
+}
+
+var _ out.YamlParser = (*YamlParser)(nil)
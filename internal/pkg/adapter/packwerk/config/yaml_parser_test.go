@@ -0,0 +1,76 @@
+package config
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+)
+
+func TestYamlParser_Parse(t *testing.T) {
+	text := "enforce_dependencies: true\ndependencies:\n  - packs/books\n  - \"packs/users\"\nvisible_to: packs/admin\n"
+
+	root, err := NewYamlParser().Parse(text)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if root.Kind != domain.YamlMapping {
+		t.Fatalf("expected mapping, got %v", root.Kind)
+	}
+
+	enforce := root.Get("enforce_dependencies")
+	if enforce == nil || enforce.Value != "true" {
+		t.Fatalf("unexpected enforce_dependencies node: %+v", enforce)
+	}
+	wantKeyRange := domain.Range{Start: domain.Position{Line: 0, Character: 0}, End: domain.Position{Line: 0, Character: 20}}
+	if enforce.KeyRange != wantKeyRange {
+		t.Errorf("unexpected key range: want %+v, got %+v", wantKeyRange, enforce.KeyRange)
+	}
+
+	items := root.Get("dependencies").Items()
+	if len(items) != 2 {
+		t.Fatalf("expected 2 dependencies, got %d", len(items))
+	}
+	tests := []struct {
+		value string
+		want  domain.Range
+	}{
+		{"packs/books", domain.Range{Start: domain.Position{Line: 2, Character: 4}, End: domain.Position{Line: 2, Character: 15}}},
+		{"packs/users", domain.Range{Start: domain.Position{Line: 3, Character: 4}, End: domain.Position{Line: 3, Character: 17}}},
+	}
+	for i, tt := range tests {
+		if items[i].Value != tt.value {
+			t.Errorf("item %d: want value %q, got %q", i, tt.value, items[i].Value)
+		}
+		if items[i].Range != tt.want {
+			t.Errorf("item %d: want range %+v, got %+v", i, tt.want, items[i].Range)
+		}
+	}
+
+	visibleTo := root.Get("visible_to").Items()
+	if len(visibleTo) != 1 || visibleTo[0].Value != "packs/admin" {
+		t.Errorf("expected scalar visible_to to be a single item, got %+v", visibleTo)
+	}
+}
+
+func TestYamlParser_Parse_Empty(t *testing.T) {
+	root, err := NewYamlParser().Parse("")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if root.Kind != domain.YamlMapping || len(root.Children) != 0 {
+		t.Errorf("expected empty mapping, got %+v", root)
+	}
+}
+
+func TestYamlParser_Parse_Invalid(t *testing.T) {
+	_, err := NewYamlParser().Parse("enforce_dependencies: true\ndependencies:\n  - packs/books\n bad: [\n")
+	var syntaxError *domain.YamlSyntaxError
+	if !errors.As(err, &syntaxError) {
+		t.Fatalf("expected YamlSyntaxError, got %v", err)
+	}
+	// yaml.v3 reports the line of the sequence the bad key breaks
+	if syntaxError.Line != 2 {
+		t.Errorf("expected error on line 2, got %d (%s)", syntaxError.Line, syntaxError.Message)
+	}
+}
@@ -0,0 +1,76 @@
+package packwerk
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+)
+
+// CustomPackwerkChecker runs the packwerk command configured by the user,
+// e.g. `docker compose exec -T web bin/packwerk`.
+type CustomPackwerkChecker struct {
+	command []string
+}
+
+func NewCustomPackwerkChecker(command ...string) *CustomPackwerkChecker {
+	return &CustomPackwerkChecker{command: command}
+}
+
+func (c *CustomPackwerkChecker) IsAvailable(rootPath string) bool {
+	if len(c.command) == 0 {
+		return false
+	}
+	name := c.command[0]
+	// Relative paths such as bin/packwerk are resolved from the project root
+	if strings.ContainsRune(name, filepath.Separator) && !filepath.IsAbs(name) {
+		_, err := os.Stat(filepath.Join(rootPath, name))
+		return err == nil
+	}
+	_, err := exec.LookPath(name)
+	return err == nil
+}
+
+func (c *CustomPackwerkChecker) RunCheck(context context.Context, rootPath string, paths ...string) ([]domain.Violation, error) {
+	if !c.IsAvailable(rootPath) {
+		return nil, CommandNotFoundError{strings.Join(c.command, " ")}
+	}
+
+	if len(paths) == 0 {
+		return []domain.Violation{}, nil
+	}
+
+	args := []string{"check", "--offenses-formatter=default", "--"}
+	args = append(args, paths...)
+
+	return runCheckCommand(context, c.newCmd(context, rootPath, args...))
+}
+
+func (c *CustomPackwerkChecker) RunCheckAll(context context.Context, rootPath string) ([]domain.Violation, error) {
+	if !c.IsAvailable(rootPath) {
+		return nil, CommandNotFoundError{strings.Join(c.command, " ")}
+	}
+	return runCheckCommand(context, c.newCmd(context, rootPath, "check", "--offenses-formatter=default"))
+}
+
+func (c *CustomPackwerkChecker) RunUpdateTodo(context context.Context, rootPath string) error {
+	if !c.IsAvailable(rootPath) {
+		return CommandNotFoundError{strings.Join(c.command, " ")}
+	}
+	return runUpdateTodo(context, c.newCmd(context, rootPath, "update-todo"))
+}
+
+func (c *CustomPackwerkChecker) newCmd(context context.Context, rootPath string, args ...string) *exec.Cmd {
+	name := c.command[0]
+	if strings.ContainsRune(name, filepath.Separator) && !filepath.IsAbs(name) {
+		name = filepath.Join(rootPath, name)
+	}
+	cmd := exec.CommandContext(context, name, append(c.command[1:len(c.command):len(c.command)], args...)...)
+	cmd.Dir = rootPath
+	return cmd
+}
+
+var _ CheckerCommand = &CustomPackwerkChecker{}
@@ -33,8 +33,7 @@ func (c *DirectPackwerkChecker) RunCheck(context context.Context, rootPath strin
 
 	cmd := exec.CommandContext(context, "packwerk", args...)
 	cmd.Dir = rootPath
-	out, _ := cmd.Output()
-	return NewPackwerkOutput(string(out)).Parse(), nil
+	return runCheckCommand(context, cmd)
 }
 
 func (c *DirectPackwerkChecker) RunCheckAll(context context.Context, rootPath string) ([]domain.Violation, error) {
@@ -43,8 +42,16 @@ func (c *DirectPackwerkChecker) RunCheckAll(context context.Context, rootPath st
 	}
 	cmd := exec.CommandContext(context, "packwerk", "check", "--offenses-formatter=default")
 	cmd.Dir = rootPath
-	out, _ := cmd.Output()
-	return NewPackwerkOutput(string(out)).Parse(), nil
+	return runCheckCommand(context, cmd)
+}
+
+func (c *DirectPackwerkChecker) RunUpdateTodo(context context.Context, rootPath string) error {
+	if !c.IsAvailable(rootPath) {
+		return CommandNotFoundError{"packwerk"}
+	}
+	cmd := exec.CommandContext(context, "packwerk", "update-todo")
+	cmd.Dir = rootPath
+	return runUpdateTodo(context, cmd)
 }
 
 var _ CheckerCommand = &DirectPackwerkChecker{}
@@ -2,6 +2,7 @@ package packwerk
 
 import (
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -11,6 +12,12 @@ import (
 var ansiEscape = regexp.MustCompile(`\x1b\[[0-9;]*[a-zA-Z]`)
 var packwerkFileLineOutputRegex = regexp.MustCompile(`^([^:]+):(\d+):(\d+)$`)
 var packwerkMessageRegex = regexp.MustCompile(`^([^:]+): `)
+var packwerkConstantRegex = regexp.MustCompile(`(?:^|[\s'])((?:::)?[A-Z]\w*(?:::[A-Z]\w*)*)'?\s`)
+var packwerkQuotedRegex = regexp.MustCompile(`'([^']+)'`)
+var packwerkHelpURLRegex = regexp.MustCompile(`see: (https?://\S+)`)
+var packwerkDefinitionRegex = regexp.MustCompile(`defined in (\S+?)\.?(?:\s|$)`)
+var packwerkSummaryRegex = regexp.MustCompile(`^(?:\d+|No) offenses? detected`)
+var packwerkCleanRegex = regexp.MustCompile(`^(?:No offenses detected|There were stale violations found)`)
 
 type PackwerkOutput struct {
 	body string
@@ -44,19 +51,69 @@ func (p *PackwerkOutput) Parse() []domain.Violation {
 					violationType = mm[1]
 				}
 			}
-			violations = append(violations, domain.Violation{
+			violation := domain.Violation{
 				File:      m[1],
 				Line:      uint32(line),
 				Character: uint32(column),
 				Message:   msg,
 				Type:      violationType,
-			})
+			}
+			if len(msgLines) > 0 {
+				parseReference(&violation, msgLines[0])
+			}
 			i += len(msgLines) // skip message lines
+
+			// The paragraphs after the message explain it, up to the next offense or the summary
+			detailLines := []string{}
+			for ; i+1 < len(lines); i++ {
+				next := lines[i+1]
+				if packwerkFileLineOutputRegex.MatchString(next) || packwerkSummaryRegex.MatchString(next) {
+					break
+				}
+				if next != "" {
+					detailLines = append(detailLines, next)
+				}
+			}
+			violation.Details = strings.Join(detailLines, " ")
+			if mm := packwerkHelpURLRegex.FindStringSubmatch(violation.Details); mm != nil {
+				violation.HelpURL = mm[1]
+			}
+			if mm := packwerkDefinitionRegex.FindStringSubmatch(violation.Details); mm != nil {
+				violation.DefinitionFile = mm[1]
+			}
+			violations = append(violations, violation)
 		}
 	}
 	return violations
 }
 
+// parseReference extracts the constant and the packs from the first line of a message, e.g.
+// "Privacy violation: '::Book' is private to 'packs/books' but referenced from 'packs/users'."
+// The first pack mentioned is the referenced one, the next different one the referencing one.
+func parseReference(violation *domain.Violation, line string) {
+	description := strings.TrimPrefix(line, violation.Type+": ")
+	if m := packwerkConstantRegex.FindStringSubmatch(description); m != nil {
+		violation.Constant = m[1]
+	}
+	for _, m := range packwerkQuotedRegex.FindAllStringSubmatch(description, -1) {
+		switch m[1] {
+		case violation.Constant, violation.ReferencedPackage:
+			continue
+		}
+		if violation.ReferencedPackage == "" {
+			violation.ReferencedPackage = m[1]
+		} else if violation.ReferencingPackage == "" {
+			violation.ReferencingPackage = m[1]
+		}
+	}
+}
+
+// IsClean reports whether packwerk summarized the check without offenses. It still exits with 1
+// when package_todo.yml lists violations that are gone.
+func (p *PackwerkOutput) IsClean() bool {
+	return slices.ContainsFunc(p.cleanOutputLines(), packwerkCleanRegex.MatchString)
+}
+
 func (p *PackwerkOutput) cleanOutputLines() []string {
 	var result []string
 	for _, line := range strings.Split(p.body, "\n") {
@@ -13,6 +13,14 @@ func TestPackwerkOutput_Parse(t *testing.T) {
 		Character uint32
 		Type      string
 		Message   string
+
+		Constant           string
+		ReferencedPackage  string
+		ReferencingPackage string
+
+		Details        string
+		HelpURL        string
+		DefinitionFile string
 	}
 
 	tests := []struct {
@@ -30,6 +38,10 @@ func TestPackwerkOutput_Parse(t *testing.T) {
 					Character: 4,
 					Type:      "Dependency violation",
 					Message:   "Dependency violation: ::Book belongs to 'packs/books', but 'packs/users' does not specify a dependency on 'packs/books'. Are we missing an abstraction? Is the code making the reference, and the referenced constant, in the right packages?",
+
+					Constant:           "::Book",
+					ReferencedPackage:  "packs/books",
+					ReferencingPackage: "packs/users",
 				},
 			},
 		},
@@ -43,6 +55,13 @@ func TestPackwerkOutput_Parse(t *testing.T) {
 					Character: 4,
 					Type:      "Dependency violation",
 					Message:   "Dependency violation: ::Book belongs to 'packs/books', but 'packs/users' does not specify a dependency on 'packs/books'. Are we missing an abstraction? Is the code making the reference, and the referenced constant, in the right packages?",
+
+					Constant:           "::Book",
+					ReferencedPackage:  "packs/books",
+					ReferencingPackage: "packs/users",
+
+					HelpURL:        "https://github.com/Shopify/packwerk/blob/main/TROUBLESHOOT.md#Troubleshooting-violations",
+					DefinitionFile: "packs/books/app/models/book.rb",
 				},
 				{
 					File:      "packs/users/app/controllers/users_controller.rb",
@@ -50,6 +69,34 @@ func TestPackwerkOutput_Parse(t *testing.T) {
 					Character: 4,
 					Type:      "Dependency violation",
 					Message:   "Dependency violation: ::Book belongs to 'packs/books', but 'packs/users' does not specify a dependency on 'packs/books'. Are we missing an abstraction? Is the code making the reference, and the referenced constant, in the right packages?",
+
+					Constant:           "::Book",
+					ReferencedPackage:  "packs/books",
+					ReferencingPackage: "packs/users",
+
+					HelpURL:        "https://github.com/Shopify/packwerk/blob/main/TROUBLESHOOT.md#Troubleshooting-violations",
+					DefinitionFile: "packs/books/app/models/book.rb",
+				},
+			},
+		},
+		{
+			name:        "privacy violation",
+			fixtureFile: "packwerk_output_privacy.txt",
+			expectedViolations: []expectedViolation{
+				{
+					File:      "packs/users/app/models/user.rb",
+					Line:      8,
+					Character: 4,
+					Type:      "Privacy violation",
+					Message:   "Privacy violation: '::Books::Inventory' is private to 'packs/books' but referenced from 'packs/users'. Is there a public entrypoint in 'packs/books/app/public/' that you can use instead?",
+
+					Constant:           "::Books::Inventory",
+					ReferencedPackage:  "packs/books",
+					ReferencingPackage: "packs/users",
+
+					Details:        "Inference details: this is a reference to ::Books::Inventory which seems to be defined in packs/books/app/models/books/inventory.rb. To receive help interpreting or resolving this error message, see: https://github.com/Shopify/packwerk/blob/main/TROUBLESHOOT.md#Troubleshooting-violations",
+					HelpURL:        "https://github.com/Shopify/packwerk/blob/main/TROUBLESHOOT.md#Troubleshooting-violations",
+					DefinitionFile: "packs/books/app/models/books/inventory.rb",
 				},
 			},
 		},
@@ -92,7 +139,43 @@ func TestPackwerkOutput_Parse(t *testing.T) {
 				if v.Message != ev.Message {
 					t.Errorf("violation %d: unexpected message:\n--- got ---\n%q\n--- want ---\n%q", i, v.Message, ev.Message)
 				}
+				if v.Constant != ev.Constant || v.ReferencedPackage != ev.ReferencedPackage || v.ReferencingPackage != ev.ReferencingPackage {
+					t.Errorf("violation %d: unexpected reference: got %q from %q to %q, want %q from %q to %q", i,
+						v.Constant, v.ReferencingPackage, v.ReferencedPackage, ev.Constant, ev.ReferencingPackage, ev.ReferencedPackage)
+				}
+				if ev.Details != "" && v.Details != ev.Details {
+					t.Errorf("violation %d: unexpected details:\n--- got ---\n%q\n--- want ---\n%q", i, v.Details, ev.Details)
+				}
+				if ev.DefinitionFile != "" && v.DefinitionFile != ev.DefinitionFile {
+					t.Errorf("violation %d: unexpected definition file: got %q, want %q", i, v.DefinitionFile, ev.DefinitionFile)
+				}
+				if ev.HelpURL != "" && v.HelpURL != ev.HelpURL {
+					t.Errorf("violation %d: unexpected help URL: got %q, want %q", i, v.HelpURL, ev.HelpURL)
+				}
 			}
 		})
 	}
 }
+
+func TestPackwerkOutput_IsClean(t *testing.T) {
+	tests := []struct {
+		fixture string
+		want    bool
+	}{
+		{"packwerk_output_empty.txt", false},
+		{"packwerk_output_stale.txt", true},
+		{"packwerk_output_single.txt", false},
+	}
+	for _, tt := range tests {
+		data, err := os.ReadFile(filepath.Join("testdata", tt.fixture))
+		if err != nil {
+			t.Fatalf("failed to read fixture: %v", err)
+		}
+		if got := NewPackwerkOutput(string(data)).IsClean(); got != tt.want {
+			t.Errorf("%s: want clean %v, got %v", tt.fixture, tt.want, got)
+		}
+	}
+	if NewPackwerkOutput("uninitialized constant Packwerk").IsClean() {
+		t.Error("want an output without summary not clean")
+	}
+}
@@ -5,7 +5,12 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
 	"github.com/rinsyan0518/wpks-ls/internal/pkg/port/out"
@@ -15,6 +20,7 @@ type CheckerCommand interface {
 	IsAvailable(rootPath string) bool
 	RunCheck(context context.Context, rootPath string, paths ...string) ([]domain.Violation, error)
 	RunCheckAll(context context.Context, rootPath string) ([]domain.Violation, error)
+	RunUpdateTodo(context context.Context, rootPath string) error
 }
 
 type CommandNotFoundError struct {
@@ -32,6 +38,16 @@ func IsCommandNotFoundError(err error) bool {
 
 type Runner struct {
 	checkers []CheckerCommand
+
+	mu          sync.Mutex
+	nextID      int
+	running     map[int]context.CancelFunc // cancels the packwerk processes in flight
+	custom      CheckerCommand             // replaces the checkers when the user configured a command
+	timeout     time.Duration
+	concurrency int
+
+	// held keeps the checks from reading package_todo.yml while update-todo rewrites it
+	held sync.RWMutex
 }
 
 func NewRunnerWithDefaultCheckers() *Runner {
@@ -43,7 +59,19 @@ func NewRunnerWithDefaultCheckers() *Runner {
 }
 
 func NewRunner(checkers ...CheckerCommand) *Runner {
-	return &Runner{checkers: checkers}
+	return &Runner{checkers: checkers, running: make(map[int]context.CancelFunc), concurrency: 1}
+}
+
+// Configure applies the settings to the checks started from now on.
+func (r *Runner) Configure(settings domain.Settings) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.custom = nil
+	if len(settings.CheckerCommand) > 0 {
+		r.custom = NewCustomPackwerkChecker(settings.CheckerCommand...)
+	}
+	r.timeout = settings.Timeout
+	r.concurrency = max(settings.Concurrency, 1)
 }
 
 func (r *Runner) IsAvailable(rootPath string) bool {
@@ -56,7 +84,7 @@ func (r *Runner) IsAvailable(rootPath string) bool {
 	return true
 }
 
-func (r *Runner) RunCheck(context context.Context, rootPath string, paths ...string) ([]domain.Violation, error) {
+func (r *Runner) RunCheck(parent context.Context, rootPath string, paths ...string) ([]domain.Violation, error) {
 	if len(paths) == 0 {
 		return []domain.Violation{}, nil
 	}
@@ -65,9 +93,49 @@ func (r *Runner) RunCheck(context context.Context, rootPath string, paths ...str
 		return []domain.Violation{}, nil
 	}
 
+	r.held.RLock()
+	defer r.held.RUnlock()
+	context, done := r.track(parent)
+	defer done()
+
+	checkers, concurrency := r.configuration()
+	chunks := splitPaths(paths, concurrency)
+	if len(chunks) == 1 {
+		return runCheck(context, checkers, rootPath, chunks[0])
+	}
+
+	results := make([][]domain.Violation, len(chunks))
+	errs := make([]error, len(chunks))
+	var wg sync.WaitGroup
+	for i, chunk := range chunks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = runCheck(context, checkers, rootPath, chunk)
+		}()
+	}
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return slices.Concat(results...), nil
+}
+
+func (r *Runner) RunCheckAll(parent context.Context, rootPath string) ([]domain.Violation, error) {
+	if !r.IsAvailable(rootPath) {
+		return []domain.Violation{}, nil
+	}
+
+	r.held.RLock()
+	defer r.held.RUnlock()
+	context, done := r.track(parent)
+	defer done()
+
+	checkers, _ := r.configuration()
 	var lastErr error
-	for _, checker := range r.checkers {
-		result, err := checker.RunCheck(context, rootPath, paths...)
+	for _, checker := range checkers {
+		result, err := checker.RunCheckAll(context, rootPath)
 		if err == nil {
 			return result, nil
 		}
@@ -75,6 +143,9 @@ func (r *Runner) RunCheck(context context.Context, rootPath string, paths ...str
 			continue // skip this checker
 		}
 		lastErr = err
+		if context.Err() != nil {
+			break // the other checkers would be stopped as well
+		}
 	}
 	if lastErr != nil {
 		return nil, lastErr
@@ -82,14 +153,132 @@ func (r *Runner) RunCheck(context context.Context, rootPath string, paths ...str
 	return nil, errors.New("no checker command succeeded")
 }
 
-func (r *Runner) RunCheckAll(context context.Context, rootPath string) ([]domain.Violation, error) {
+// RunUpdateTodo regenerates the package_todo.yml files of every pack.
+func (r *Runner) RunUpdateTodo(parent context.Context, rootPath string) error {
 	if !r.IsAvailable(rootPath) {
-		return []domain.Violation{}, nil
+		return errors.New("packwerk.yml not found")
 	}
 
+	context, done := r.track(parent)
+	defer done()
+
+	checkers, _ := r.configuration()
 	var lastErr error
-	for _, checker := range r.checkers {
-		result, err := checker.RunCheckAll(context, rootPath)
+	for _, checker := range checkers {
+		err := checker.RunUpdateTodo(context, rootPath)
+		if err == nil {
+			return nil
+		}
+		if IsCommandNotFoundError(err) {
+			continue // skip this checker
+		}
+		lastErr = err
+		if context.Err() != nil {
+			break // the other checkers would be stopped as well
+		}
+	}
+	if lastErr != nil {
+		return lastErr
+	}
+	return errors.New("no checker command succeeded")
+}
+
+// HoldChecks waits for the checks in flight, and makes the next ones wait until release is called.
+func (r *Runner) HoldChecks() (release func()) {
+	r.held.Lock()
+	return sync.OnceFunc(r.held.Unlock)
+}
+
+// ClearCache removes the cache packwerk keeps between runs.
+func (r *Runner) ClearCache(rootPath string, cacheDirectory string) error {
+	directory, err := cacheDirectoryPath(rootPath, cacheDirectory)
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(directory)
+}
+
+// cacheDirectoryPath resolves the cache directory. Everything below it is removed, so it must be
+// a directory inside the project other than the project itself, also once symbolic links are followed.
+func cacheDirectoryPath(rootPath string, cacheDirectory string) (string, error) {
+	relative := filepath.Clean(filepath.FromSlash(cacheDirectory))
+	if !filepath.IsLocal(relative) || relative == "." {
+		return "", fmt.Errorf("invalid cache directory %q: expected a directory inside the project", cacheDirectory)
+	}
+	directory := filepath.Join(rootPath, relative)
+
+	root, err := filepath.EvalSymlinks(rootPath)
+	if err != nil {
+		return "", err
+	}
+	resolved, err := filepath.EvalSymlinks(directory)
+	if errors.Is(err, os.ErrNotExist) {
+		// Nothing to remove
+		return directory, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if inside, err := filepath.Rel(root, resolved); err != nil || !filepath.IsLocal(inside) || inside == "." {
+		return "", fmt.Errorf("invalid cache directory %q: it leads to %s, outside of the project", cacheDirectory, resolved)
+	}
+	return directory, nil
+}
+
+// Restart kills the packwerk processes in flight, so the next check starts from scratch.
+func (r *Runner) Restart() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for id, cancel := range r.running {
+		cancel()
+		delete(r.running, id)
+	}
+}
+
+// configuration returns the checkers to try in order and how many processes a check may use.
+func (r *Runner) configuration() ([]CheckerCommand, int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.custom != nil {
+		return []CheckerCommand{r.custom}, r.concurrency
+	}
+	return r.checkers, r.concurrency
+}
+
+// track derives a context that Restart cancels and the configured timeout expires.
+// done must be called once the command finished.
+func (r *Runner) track(parent context.Context) (context.Context, func()) {
+	r.mu.Lock()
+	timeout := r.timeout
+	r.mu.Unlock()
+
+	var ctx context.Context
+	var cancel context.CancelFunc
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(parent, timeout)
+	} else {
+		ctx, cancel = context.WithCancel(parent)
+	}
+
+	r.mu.Lock()
+	id := r.nextID
+	r.nextID++
+	r.running[id] = cancel
+	r.mu.Unlock()
+
+	return ctx, func() {
+		r.mu.Lock()
+		delete(r.running, id)
+		r.mu.Unlock()
+		cancel()
+	}
+}
+
+// runCheck tries the checkers in order until one of them can check the paths.
+func runCheck(context context.Context, checkers []CheckerCommand, rootPath string, paths []string) ([]domain.Violation, error) {
+	var lastErr error
+	for _, checker := range checkers {
+		result, err := checker.RunCheck(context, rootPath, paths...)
 		if err == nil {
 			return result, nil
 		}
@@ -97,6 +286,9 @@ func (r *Runner) RunCheckAll(context context.Context, rootPath string) ([]domain
 			continue // skip this checker
 		}
 		lastErr = err
+		if context.Err() != nil {
+			break // the other checkers would be stopped as well
+		}
 	}
 	if lastErr != nil {
 		return nil, lastErr
@@ -104,4 +296,53 @@ func (r *Runner) RunCheckAll(context context.Context, rootPath string) ([]domain
 	return nil, errors.New("no checker command succeeded")
 }
 
+// splitPaths distributes the paths over at most n chunks of similar size.
+func splitPaths(paths []string, n int) [][]string {
+	n = min(max(n, 1), len(paths))
+	chunks := make([][]string, 0, n)
+	for i := range n {
+		chunks = append(chunks, paths[i*len(paths)/n:(i+1)*len(paths)/n])
+	}
+	return chunks
+}
+
+// waitDelay bounds how long the output of a killed packwerk is waited for,
+// since processes it started may keep the pipes open.
+const waitDelay = time.Second
+
+// runCheckCommand runs packwerk check and parses the violations it prints. packwerk exits with 1
+// when it finds offenses or stale todos, so a failed command is only an error when neither
+// violations nor a summary without offenses were parsed.
+// A check that was stopped is an error, since its output is incomplete.
+func runCheckCommand(context context.Context, cmd *exec.Cmd) ([]domain.Violation, error) {
+	cmd.WaitDelay = waitDelay
+	out, err := cmd.Output()
+	if contextErr := context.Err(); contextErr != nil {
+		return nil, fmt.Errorf("packwerk check was stopped: %w", contextErr)
+	}
+	output := NewPackwerkOutput(string(out))
+	violations := output.Parse()
+	if err != nil && len(violations) == 0 && !output.IsClean() {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return nil, fmt.Errorf("packwerk check failed: %w: %s", err, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, fmt.Errorf("packwerk check failed: %w", err)
+	}
+	return violations, nil
+}
+
+// runUpdateTodo runs packwerk update-todo, reporting its output when it fails.
+func runUpdateTodo(context context.Context, cmd *exec.Cmd) error {
+	cmd.WaitDelay = waitDelay
+	out, err := cmd.CombinedOutput()
+	if contextErr := context.Err(); contextErr != nil {
+		return fmt.Errorf("packwerk update-todo was stopped: %w", contextErr)
+	}
+	if err != nil {
+		return fmt.Errorf("packwerk update-todo failed: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
 var _ out.PackwerkRunner = (*Runner)(nil)
@@ -0,0 +1,307 @@
+package packwerk
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+)
+
+// fakeChecker records its calls. A blocking checker waits until its context is cancelled.
+type fakeChecker struct {
+	name        string
+	available   bool
+	blocking    bool
+	started     chan struct{}
+	updatedTodo bool
+
+	mu      sync.Mutex
+	checked [][]string // paths of every RunCheck call
+}
+
+func (c *fakeChecker) IsAvailable(rootPath string) bool {
+	return c.available
+}
+
+func (c *fakeChecker) RunCheck(ctx context.Context, rootPath string, paths ...string) ([]domain.Violation, error) {
+	c.mu.Lock()
+	c.checked = append(c.checked, paths)
+	c.mu.Unlock()
+	violations, err := c.RunCheckAll(ctx, rootPath)
+	for _, path := range paths {
+		violations = append(violations, domain.Violation{File: path})
+	}
+	return violations, err
+}
+
+func (c *fakeChecker) RunCheckAll(ctx context.Context, rootPath string) ([]domain.Violation, error) {
+	if !c.available {
+		return nil, CommandNotFoundError{c.name}
+	}
+	if c.blocking {
+		close(c.started)
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	return []domain.Violation{}, nil
+}
+
+func (c *fakeChecker) RunUpdateTodo(ctx context.Context, rootPath string) error {
+	if !c.available {
+		return CommandNotFoundError{c.name}
+	}
+	c.updatedTodo = true
+	return nil
+}
+
+func setupPackwerkProject(t *testing.T) string {
+	t.Helper()
+	rootPath := t.TempDir()
+	if err := os.WriteFile(filepath.Join(rootPath, "packwerk.yml"), []byte(""), 0o644); err != nil {
+		t.Fatalf("failed to write packwerk.yml: %v", err)
+	}
+	return rootPath
+}
+
+func TestRunner_RunUpdateTodo(t *testing.T) {
+	rootPath := setupPackwerkProject(t)
+	missing := &fakeChecker{name: "bin/packwerk"}
+	available := &fakeChecker{name: "packwerk", available: true}
+
+	if err := NewRunner(missing, available).RunUpdateTodo(context.Background(), rootPath); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !available.updatedTodo {
+		t.Error("expected the first available checker to run update-todo")
+	}
+
+	if err := NewRunner(missing).RunUpdateTodo(context.Background(), rootPath); err == nil {
+		t.Error("expected an error when no checker is available")
+	}
+}
+
+func TestRunner_HoldChecks(t *testing.T) {
+	rootPath := setupPackwerkProject(t)
+	runner := NewRunner(&fakeChecker{name: "packwerk", available: true})
+
+	release := runner.HoldChecks()
+	checked := make(chan error, 1)
+	go func() {
+		_, err := runner.RunCheckAll(context.Background(), rootPath)
+		checked <- err
+	}()
+	select {
+	case <-checked:
+		t.Fatal("expected the check to wait while held")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	release()
+	release() // releasing twice is harmless
+	select {
+	case err := <-checked:
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the check to run once released")
+	}
+}
+
+func TestRunner_ClearCache(t *testing.T) {
+	rootPath := setupPackwerkProject(t)
+	cachePath := filepath.Join(rootPath, "tmp", "cache", "packwerk")
+	if err := os.MkdirAll(cachePath, 0o755); err != nil {
+		t.Fatalf("failed to create cache: %v", err)
+	}
+
+	if err := NewRunner().ClearCache(rootPath, "tmp/cache/packwerk"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := os.Stat(cachePath); !os.IsNotExist(err) {
+		t.Errorf("expected the cache to be removed, got %v", err)
+	}
+}
+
+func TestRunner_ClearCache_Outside(t *testing.T) {
+	rootPath := setupPackwerkProject(t)
+	outside := t.TempDir()
+	if err := os.Symlink(outside, filepath.Join(rootPath, "tmp")); err != nil {
+		t.Fatalf("failed to create symlink: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Join(outside, "cache"), 0o755); err != nil {
+		t.Fatalf("failed to create cache: %v", err)
+	}
+
+	for _, cacheDirectory := range []string{"", ".", "..", "../..", "packs/..", outside, "tmp/cache"} {
+		if err := NewRunner().ClearCache(rootPath, cacheDirectory); err == nil {
+			t.Errorf("want cache directory %q rejected", cacheDirectory)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(rootPath, domain.PackwerkConfigFile)); err != nil {
+		t.Errorf("want the project kept, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(outside, "cache")); err != nil {
+		t.Errorf("want the directory behind the symlink kept, got %v", err)
+	}
+}
+
+func TestRunner_Restart(t *testing.T) {
+	rootPath := setupPackwerkProject(t)
+	checker := &fakeChecker{name: "packwerk", available: true, blocking: true, started: make(chan struct{})}
+	runner := NewRunner(checker)
+
+	errs := make(chan error, 1)
+	go func() {
+		_, err := runner.RunCheckAll(context.Background(), rootPath)
+		errs <- err
+	}()
+
+	<-checker.started
+	runner.Restart()
+
+	select {
+	case err := <-errs:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("want the check to be cancelled, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("the check was not cancelled by Restart")
+	}
+}
+
+func TestRunner_Configure(t *testing.T) {
+	rootPath := setupPackwerkProject(t)
+
+	t.Run("concurrency splits the paths", func(t *testing.T) {
+		checker := &fakeChecker{name: "packwerk", available: true}
+		runner := NewRunner(checker)
+		runner.Configure(domain.Settings{Concurrency: 2})
+
+		violations, err := runner.RunCheck(context.Background(), rootPath, "a.rb", "b.rb", "c.rb")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(checker.checked) != 2 {
+			t.Errorf("want 2 packwerk processes, got %v", checker.checked)
+		}
+		files := make([]string, 0, len(violations))
+		for _, v := range violations {
+			files = append(files, v.File)
+		}
+		slices.Sort(files)
+		if !slices.Equal(files, []string{"a.rb", "b.rb", "c.rb"}) {
+			t.Errorf("want the violations of every path, got %v", files)
+		}
+	})
+
+	t.Run("custom command replaces the fallback order", func(t *testing.T) {
+		checker := &fakeChecker{name: "packwerk", available: true}
+		runner := NewRunner(checker)
+		runner.Configure(domain.Settings{CheckerCommand: []string{"wpks-ls-missing-packwerk"}})
+
+		if _, err := runner.RunCheckAll(context.Background(), rootPath); err == nil {
+			t.Error("expected an error when the custom command is not available")
+		}
+
+		runner.Configure(domain.NewSettings())
+		if _, err := runner.RunCheckAll(context.Background(), rootPath); err != nil {
+			t.Errorf("expected the default checkers after the command was unset, got %v", err)
+		}
+	})
+
+	t.Run("timeout cancels the check", func(t *testing.T) {
+		checker := &fakeChecker{name: "packwerk", available: true, blocking: true, started: make(chan struct{})}
+		runner := NewRunner(checker)
+		runner.Configure(domain.Settings{Timeout: 10 * time.Millisecond})
+
+		if _, err := runner.RunCheckAll(context.Background(), rootPath); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("want the check to time out, got %v", err)
+		}
+	})
+}
+
+func TestRunner_CommandFailures(t *testing.T) {
+	rootPath := setupPackwerkProject(t)
+	output, err := os.ReadFile(filepath.Join("testdata", "packwerk_output_single.txt"))
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(rootPath, "offenses.txt"), output, 0o644); err != nil {
+		t.Fatalf("failed to write offenses: %v", err)
+	}
+	stale, err := os.ReadFile(filepath.Join("testdata", "packwerk_output_stale.txt"))
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(rootPath, "stale.txt"), stale, 0o644); err != nil {
+		t.Fatalf("failed to write stale output: %v", err)
+	}
+
+	tests := []struct {
+		name           string
+		script         string
+		timeout        time.Duration
+		wantViolations int
+		wantErr        string
+	}{
+		{name: "offenses exit with 1", script: "cat offenses.txt; exit 1", wantViolations: 1},
+		{name: "no offenses", script: "echo 'No offenses detected'", wantViolations: 0},
+		{name: "stale todos exit with 1", script: "cat stale.txt; exit 1", wantViolations: 0},
+		{name: "offenses not parsed", script: "echo '1 offense detected'; exit 1", wantErr: "packwerk check failed"},
+		{name: "crash", script: "echo 'uninitialized constant Packwerk' >&2; exit 1", wantErr: "uninitialized constant Packwerk"},
+		// The child keeps the pipes open after the shell is killed
+		{name: "timeout", script: "sleep 5 & sleep 5", timeout: 200 * time.Millisecond, wantErr: "stopped: context deadline exceeded"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := NewRunner()
+			runner.Configure(domain.Settings{CheckerCommand: []string{"sh", "-c", tt.script}, Timeout: tt.timeout})
+
+			start := time.Now()
+			violations, err := runner.RunCheckAll(context.Background(), rootPath)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("want an error with %q, got %d violations and %v", tt.wantErr, len(violations), err)
+				}
+			} else if err != nil || len(violations) != tt.wantViolations {
+				t.Errorf("want %d violations, got %d and %v", tt.wantViolations, len(violations), err)
+			}
+			if elapsed := time.Since(start); elapsed > 3*time.Second {
+				t.Errorf("want the check to be capped, took %s", elapsed)
+			}
+		})
+	}
+}
+
+func TestSplitPaths(t *testing.T) {
+	tests := []struct {
+		name  string
+		paths []string
+		n     int
+		want  [][]string
+	}{
+		{name: "single chunk", paths: []string{"a", "b"}, n: 1, want: [][]string{{"a", "b"}}},
+		{name: "zero is one chunk", paths: []string{"a", "b"}, n: 0, want: [][]string{{"a", "b"}}},
+		{name: "even split", paths: []string{"a", "b", "c", "d"}, n: 2, want: [][]string{{"a", "b"}, {"c", "d"}}},
+		{name: "uneven split", paths: []string{"a", "b", "c"}, n: 2, want: [][]string{{"a"}, {"b", "c"}}},
+		{name: "more chunks than paths", paths: []string{"a", "b"}, n: 4, want: [][]string{{"a"}, {"b"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := splitPaths(tt.paths, tt.n)
+			if !slices.EqualFunc(got, tt.want, slices.Equal) {
+				t.Errorf("splitPaths(%v, %d) = %v, want %v", tt.paths, tt.n, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+)
+
+// GitHubReporter writes the results as workflow commands of GitHub Actions,
+// which annotate the lines of the pull request, e.g.
+// "::error file=app/models/user.rb,line=8,col=5,title=Privacy violation::message".
+type GitHubReporter struct{}
+
+func NewGitHubReporter() *GitHubReporter {
+	return &GitHubReporter{}
+}
+
+func (r *GitHubReporter) Report(w io.Writer, results []Result) error {
+	for _, result := range results {
+		v := result.Violation
+		properties := []string{
+			"file=" + escapeProperty(v.File),
+			fmt.Sprintf("line=%d", v.Line),
+			// Workflow command columns are 1-based, packwerk's are 0-based
+			fmt.Sprintf("col=%d", v.Character+1),
+		}
+		if v.Type != "" {
+			properties = append(properties, "title="+escapeProperty(v.Type))
+		}
+		if _, err := fmt.Fprintf(w, "::%s %s::%s\n", githubCommand(result.Severity), strings.Join(properties, ","), escapeData(result.Message)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func githubCommand(severity int32) string {
+	switch severity {
+	case domain.SeverityError:
+		return "error"
+	case domain.SeverityWarning:
+		return "warning"
+	}
+	return "notice"
+}
+
+// escapeData escapes the message of a workflow command.
+func escapeData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// escapeProperty escapes a property value, which is also delimited by ":" and ",".
+func escapeProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
+
+var _ Reporter = (*GitHubReporter)(nil)
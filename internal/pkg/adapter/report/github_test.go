@@ -0,0 +1,29 @@
+package report
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+)
+
+func TestGitHubReporter(t *testing.T) {
+	results := append(testResults(), Result{
+		Violation: domain.Violation{File: "app/models/a,b.rb", Line: 1, Character: 0, Type: "Layer violation"},
+		Severity:  domain.SeverityHint,
+		Message:   "100% wrong\nsecond line",
+	})
+
+	var b bytes.Buffer
+	if err := Write(&b, NewGitHubReporter(), results); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "::notice file=app/models/a%2Cb.rb,line=1,col=1,title=Layer violation::100%25 wrong%0Asecond line\n" +
+		"::error file=packs/users/app/controllers/users_controller.rb,line=20,col=5,title=Dependency violation::::Book → packs/books (no dependency from packs/users)\n" +
+		"::error file=packs/users/app/controllers/users_controller.rb,line=26,col=5,title=Dependency violation::::Book → packs/books (no dependency from packs/users)\n" +
+		"::warning file=packs/users/app/models/user.rb,line=8,col=5,title=Privacy violation::::Books::Inventory → packs/books (private, referenced from packs/users)\n"
+	if b.String() != want {
+		t.Errorf("unexpected output:\n--- got ---\n%s\n--- want ---\n%s", b.String(), want)
+	}
+}
@@ -0,0 +1,65 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+)
+
+// JSONReporter writes the results as a JSON document for scripts.
+// Violations use the fields of the data of the LSP diagnostics, plus their severity and messages.
+type JSONReporter struct{}
+
+type jsonReport struct {
+	Violations []jsonViolation `json:"violations"`
+}
+
+type jsonViolation struct {
+	File               string `json:"file"`
+	Line               uint32 `json:"line"`
+	Character          uint32 `json:"character"`
+	Type               string `json:"type"`
+	Severity           string `json:"severity"`
+	Message            string `json:"message"`
+	FullMessage        string `json:"fullMessage"`
+	Constant           string `json:"constant,omitempty"`
+	ReferencedPackage  string `json:"referencedPackage,omitempty"`
+	ReferencingPackage string `json:"referencingPackage,omitempty"`
+	Fingerprint        string `json:"fingerprint"`
+}
+
+func NewJSONReporter() *JSONReporter {
+	return &JSONReporter{}
+}
+
+func (r *JSONReporter) Report(w io.Writer, results []Result) error {
+	report := jsonReport{Violations: make([]jsonViolation, 0, len(results))}
+	for _, result := range results {
+		v := result.Violation
+		report.Violations = append(report.Violations, jsonViolation{
+			File:               v.File,
+			Line:               v.Line,
+			Character:          v.Character,
+			Type:               v.Kind(),
+			Severity:           domain.SeverityName(result.Severity),
+			Message:            result.Message,
+			FullMessage:        v.Message,
+			Constant:           v.Constant,
+			ReferencedPackage:  v.ReferencedPackage,
+			ReferencingPackage: v.ReferencingPackage,
+			Fingerprint:        v.Fingerprint(),
+		})
+	}
+	return writeJSON(w, report)
+}
+
+// writeJSON writes the value indented, keeping characters such as "→" and "<" as they are.
+func writeJSON(w io.Writer, value any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+	return encoder.Encode(value)
+}
+
+var _ Reporter = (*JSONReporter)(nil)
@@ -0,0 +1,23 @@
+package report
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestJSONReporter(t *testing.T) {
+	var b bytes.Buffer
+	if err := Write(&b, NewJSONReporter(), testResults()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want, err := os.ReadFile(filepath.Join("testdata", "report.json"))
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+	if b.String() != string(want) {
+		t.Errorf("unexpected report:\n--- got ---\n%s\n--- want ---\n%s", b.String(), want)
+	}
+}
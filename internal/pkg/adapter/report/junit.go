@@ -0,0 +1,97 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// junitSuite names the test suite the files are reported in.
+const junitSuite = "packwerk"
+
+// JUnitReporter writes the results as JUnit XML, with one failing test case per file with violations.
+type JUnitReporter struct{}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	File      string        `xml:"file,attr"`
+	Failure   *junitFailure `xml:"failure"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",cdata"`
+}
+
+func NewJUnitReporter() *JUnitReporter {
+	return &JUnitReporter{}
+}
+
+func (r *JUnitReporter) Report(w io.Writer, results []Result) error {
+	suite := junitTestSuite{Name: junitSuite, Cases: []junitTestCase{}}
+	// Results are sorted, so the results of a file follow each other
+	for start := 0; start < len(results); {
+		file := results[start].Violation.File
+		end := start
+		var text strings.Builder
+		var kinds []string
+		for ; end < len(results) && results[end].Violation.File == file; end++ {
+			text.WriteString(textLine(results[end]) + "\n")
+			if kind := results[end].Violation.Kind(); !slices.Contains(kinds, kind) {
+				kinds = append(kinds, kind)
+			}
+		}
+
+		message := "1 violation"
+		if count := end - start; count > 1 {
+			message = fmt.Sprintf("%d violations", count)
+		}
+		suite.Cases = append(suite.Cases, junitTestCase{
+			Name:      file,
+			ClassName: junitSuite,
+			File:      file,
+			Failure:   &junitFailure{Message: message, Type: strings.Join(kinds, ","), Text: text.String()},
+		})
+		start = end
+	}
+	suite.Tests = len(suite.Cases)
+	suite.Failures = len(suite.Cases)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	err := encoder.Encode(junitTestSuites{
+		Name:     toolName,
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Suites:   []junitTestSuite{suite},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, "\n")
+	return err
+}
+
+var _ Reporter = (*JUnitReporter)(nil)
@@ -0,0 +1,37 @@
+package report
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestJUnitReporter(t *testing.T) {
+	var b bytes.Buffer
+	if err := Write(&b, NewJUnitReporter(), testResults()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want, err := os.ReadFile(filepath.Join("testdata", "report.xml"))
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+	if b.String() != string(want) {
+		t.Errorf("unexpected report:\n--- got ---\n%s\n--- want ---\n%s", b.String(), want)
+	}
+}
+
+func TestJUnitReporter_NoResults(t *testing.T) {
+	var b bytes.Buffer
+	if err := Write(&b, NewJUnitReporter(), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `<?xml version="1.0" encoding="UTF-8"?>` + "\n" +
+		`<testsuites name="wpks-ls" tests="0" failures="0">` + "\n" +
+		`  <testsuite name="packwerk" tests="0" failures="0"></testsuite>` + "\n" +
+		`</testsuites>` + "\n"
+	if b.String() != want {
+		t.Errorf("unexpected report:\n--- got ---\n%s\n--- want ---\n%s", b.String(), want)
+	}
+}
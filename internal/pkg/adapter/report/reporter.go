@@ -0,0 +1,45 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Reporter writes the results of a check in one format.
+type Reporter interface {
+	// Report writes the results, which are sorted by file and position.
+	Report(w io.Writer, results []Result) error
+}
+
+// reporters create the reporter of each format
+var reporters = map[string]func() Reporter{
+	"text":   func() Reporter { return NewTextReporter() },
+	"json":   func() Reporter { return NewJSONReporter() },
+	"sarif":  func() Reporter { return NewSarifReporter() },
+	"github": func() Reporter { return NewGitHubReporter() },
+	"junit":  func() Reporter { return NewJUnitReporter() },
+}
+
+// Formats returns the names of the formats, sorted.
+func Formats() []string {
+	return slices.Sorted(maps.Keys(reporters))
+}
+
+// NewReporter returns the reporter of the format, e.g. "sarif".
+func NewReporter(format string) (Reporter, error) {
+	newReporter, ok := reporters[format]
+	if !ok {
+		return nil, fmt.Errorf("unknown format %q. Expected one of %s", format, strings.Join(Formats(), ", "))
+	}
+	return newReporter(), nil
+}
+
+// Write sorts a copy of the results and writes them with the reporter.
+func Write(w io.Writer, reporter Reporter, results []Result) error {
+	results = slices.Clone(results)
+	SortResults(results)
+	return reporter.Report(w, results)
+}
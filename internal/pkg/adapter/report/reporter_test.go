@@ -0,0 +1,62 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+)
+
+const testHelpURL = "https://github.com/Shopify/packwerk/blob/main/TROUBLESHOOT.md#Troubleshooting-violations"
+
+// testResults are two references to the same constant and a privacy violation, out of order.
+func testResults() []Result {
+	return []Result{
+		{
+			Violation: domain.Violation{
+				File: "packs/users/app/models/user.rb", Line: 8, Character: 4, Type: "Privacy violation",
+				Message:  "Privacy violation: '::Books::Inventory' is private to 'packs/books' but referenced from 'packs/users'.",
+				Constant: "::Books::Inventory", ReferencedPackage: "packs/books", ReferencingPackage: "packs/users",
+				HelpURL: testHelpURL,
+			},
+			Severity: domain.SeverityWarning,
+			Message:  "::Books::Inventory → packs/books (private, referenced from packs/users)",
+		},
+		{
+			Violation: domain.Violation{
+				File: "packs/users/app/controllers/users_controller.rb", Line: 26, Character: 4, Type: "Dependency violation",
+				Message:  "Dependency violation: ::Book belongs to 'packs/books', but 'packs/users' does not specify a dependency on 'packs/books'.",
+				Constant: "::Book", ReferencedPackage: "packs/books", ReferencingPackage: "packs/users",
+				HelpURL: testHelpURL,
+			},
+			Severity: domain.SeverityError,
+			Message:  "::Book → packs/books (no dependency from packs/users)",
+		},
+		{
+			Violation: domain.Violation{
+				File: "packs/users/app/controllers/users_controller.rb", Line: 20, Character: 4, Type: "Dependency violation",
+				Message:  "Dependency violation: ::Book belongs to 'packs/books', but 'packs/users' does not specify a dependency on 'packs/books'.",
+				Constant: "::Book", ReferencedPackage: "packs/books", ReferencingPackage: "packs/users",
+				HelpURL: testHelpURL,
+			},
+			Severity: domain.SeverityError,
+			Message:  "::Book → packs/books (no dependency from packs/users)",
+		},
+	}
+}
+
+func sortedTestResults() []Result {
+	results := testResults()
+	SortResults(results)
+	return results
+}
+
+func TestNewReporter(t *testing.T) {
+	for _, format := range Formats() {
+		if reporter, err := NewReporter(format); err != nil || reporter == nil {
+			t.Errorf("%s: want a reporter, got %v", format, err)
+		}
+	}
+	if _, err := NewReporter("xml"); err == nil || err.Error() != `unknown format "xml". Expected one of github, json, junit, sarif, text` {
+		t.Errorf("want an unknown format error, got %v", err)
+	}
+}
@@ -0,0 +1,26 @@
+package report
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+)
+
+// Result is a violation reported by a check, with the severity and message the settings give it.
+type Result struct {
+	Violation domain.Violation
+	Severity  int32
+	Message   string // formatted with the message template
+}
+
+// SortResults orders the results by file and position, so that reports do not depend on the order packwerk prints them.
+func SortResults(results []Result) {
+	slices.SortStableFunc(results, func(a, b Result) int {
+		return cmp.Or(
+			cmp.Compare(a.Violation.File, b.Violation.File),
+			cmp.Compare(a.Violation.Line, b.Violation.Line),
+			cmp.Compare(a.Violation.Character, b.Violation.Character),
+		)
+	})
+}
@@ -0,0 +1,195 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+)
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	// sarifSourceRoot is the base of the artifact URIs, which are relative to the project root
+	sarifSourceRoot = "%SRCROOT%"
+	// sarifFingerprint names the fingerprint of the results, versioned in case its inputs change
+	sarifFingerprint = "wpksViolation/v1"
+
+	toolName           = "wpks-ls"
+	toolInformationURI = "https://github.com/rinsyan0518/wpks-ls"
+	packwerkHelpURI    = "https://github.com/Shopify/packwerk/blob/main/TROUBLESHOOT.md"
+)
+
+// ruleDescriptions describe the violation types packwerk and packwerk-extensions report.
+var ruleDescriptions = map[string]string{
+	"dependency":     "A pack references a constant of a pack that is not listed in its dependencies.",
+	"privacy":        "A pack references a private constant of another pack instead of its public API.",
+	"layer":          "A pack references a constant of a pack in a higher layer.",
+	"visibility":     "A pack references a constant of a pack that is not visible to it.",
+	"folder_privacy": "A pack references a constant of a pack in a folder that is private to it.",
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	Name                 string             `json:"name"`
+	ShortDescription     sarifMessage       `json:"shortDescription"`
+	FullDescription      *sarifMessage      `json:"fullDescription,omitempty"`
+	HelpURI              string             `json:"helpUri"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID              string            `json:"ruleId"`
+	RuleIndex           int               `json:"ruleIndex"`
+	Level               string            `json:"level"`
+	Message             sarifMessage      `json:"message"`
+	Locations           []sarifLocation   `json:"locations"`
+	PartialFingerprints map[string]string `json:"partialFingerprints"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId"`
+}
+
+type sarifRegion struct {
+	StartLine   uint32 `json:"startLine"`
+	StartColumn uint32 `json:"startColumn"`
+}
+
+// SarifReporter writes the results as a SARIF 2.1.0 log with one rule per violation type.
+// Messages are the full messages of packwerk, since code scanning has room for them.
+type SarifReporter struct{}
+
+func NewSarifReporter() *SarifReporter {
+	return &SarifReporter{}
+}
+
+func (r *SarifReporter) Report(w io.Writer, results []Result) error {
+	return writeJSON(w, newSarifLog(results))
+}
+
+func newSarifLog(results []Result) sarifLog {
+	rules := make([]sarifRule, 0, len(domain.ViolationKinds))
+	ruleIndexes := make(map[string]int)
+	addRule := func(kind string) int {
+		if index, ok := ruleIndexes[kind]; ok {
+			return index
+		}
+		ruleIndexes[kind] = len(rules)
+		rules = append(rules, newSarifRule(kind))
+		return ruleIndexes[kind]
+	}
+	// Every known type has a rule, so that dashboards list them even without results
+	for _, kind := range domain.ViolationKinds {
+		addRule(kind)
+	}
+
+	// The n-th reference of a fingerprint in its file, so that several references stay apart
+	occurrences := make(map[string]int)
+	sarifResults := make([]sarifResult, 0, len(results))
+	for _, r := range results {
+		v := r.Violation
+		kind := v.Kind()
+		fingerprint := v.Fingerprint()
+		occurrences[fingerprint]++
+
+		sarifResults = append(sarifResults, sarifResult{
+			RuleID:    kind,
+			RuleIndex: addRule(kind),
+			Level:     sarifLevel(r.Severity),
+			Message:   sarifMessage{Text: v.Message},
+			Locations: []sarifLocation{{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: v.File, URIBaseID: sarifSourceRoot},
+					// SARIF columns are 1-based, packwerk's are 0-based
+					Region: sarifRegion{StartLine: v.Line, StartColumn: v.Character + 1},
+				},
+			}},
+			PartialFingerprints: map[string]string{
+				sarifFingerprint: fmt.Sprintf("%s:%d", fingerprint, occurrences[fingerprint]),
+			},
+		})
+	}
+
+	return sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []sarifRun{{
+			Tool:    sarifTool{Driver: sarifDriver{Name: toolName, InformationURI: toolInformationURI, Rules: rules}},
+			Results: sarifResults,
+		}},
+	}
+}
+
+// newSarifRule describes a violation type. packwerk fails the check on every type, hence the level error.
+func newSarifRule(kind string) sarifRule {
+	title := strings.TrimSpace(strings.ReplaceAll(kind, "_", " ") + " violation")
+	rule := sarifRule{
+		ID:                   kind,
+		Name:                 ruleName(kind),
+		ShortDescription:     sarifMessage{Text: strings.ToUpper(title[:1]) + title[1:]},
+		HelpURI:              packwerkHelpURI,
+		DefaultConfiguration: sarifConfiguration{Level: sarifLevel(domain.SeverityError)},
+	}
+	if description, ok := ruleDescriptions[kind]; ok {
+		rule.FullDescription = &sarifMessage{Text: description}
+	}
+	return rule
+}
+
+// ruleName converts the kind into the PascalCase name SARIF recommends, e.g. "FolderPrivacyViolation".
+func ruleName(kind string) string {
+	return domain.Camelize(kind) + "Violation"
+}
+
+func sarifLevel(severity int32) string {
+	switch severity {
+	case domain.SeverityError:
+		return "error"
+	case domain.SeverityWarning:
+		return "warning"
+	}
+	return "note"
+}
+
+var _ Reporter = (*SarifReporter)(nil)
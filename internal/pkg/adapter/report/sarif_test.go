@@ -0,0 +1,60 @@
+package report
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+)
+
+func TestSarifReporter(t *testing.T) {
+	var b bytes.Buffer
+	if err := Write(&b, NewSarifReporter(), testResults()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want, err := os.ReadFile(filepath.Join("testdata", "report.sarif"))
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+	if b.String() != string(want) {
+		t.Errorf("unexpected log:\n--- got ---\n%s\n--- want ---\n%s", b.String(), want)
+	}
+}
+
+func TestSarifReporter_StableFingerprints(t *testing.T) {
+	before := newSarifLog(sortedTestResults())
+
+	// Lines added above the references move them, but keep their fingerprints
+	results := sortedTestResults()
+	for i := range results {
+		results[i].Violation.Line += 3
+	}
+	after := newSarifLog(results)
+
+	for i := range before.Runs[0].Results {
+		b, a := before.Runs[0].Results[i], after.Runs[0].Results[i]
+		if b.PartialFingerprints[sarifFingerprint] != a.PartialFingerprints[sarifFingerprint] {
+			t.Errorf("result %d: want a stable fingerprint, got %v and %v", i, b.PartialFingerprints, a.PartialFingerprints)
+		}
+	}
+}
+
+func TestSarifReporter_UnknownKind(t *testing.T) {
+	log := newSarifLog([]Result{{
+		Violation: domain.Violation{File: "app/models/a.rb", Line: 1, Type: "Architecture violation"},
+		Severity:  domain.SeverityHint,
+	}})
+
+	rules := log.Runs[0].Tool.Driver.Rules
+	rule := rules[len(rules)-1]
+	if rule.ID != "architecture" || rule.Name != "ArchitectureViolation" || rule.ShortDescription.Text != "Architecture violation" {
+		t.Errorf("unexpected rule: %+v", rule)
+	}
+	result := log.Runs[0].Results[0]
+	if result.RuleIndex != len(rules)-1 || result.Level != "note" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
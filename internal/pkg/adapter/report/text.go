@@ -0,0 +1,36 @@
+package report
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+)
+
+// TextReporter writes one line per result, with the position as packwerk prints it:
+// a 1-based line and a 0-based column.
+type TextReporter struct{}
+
+func NewTextReporter() *TextReporter {
+	return &TextReporter{}
+}
+
+func (r *TextReporter) Report(w io.Writer, results []Result) error {
+	for _, result := range results {
+		if _, err := fmt.Fprintln(w, textLine(result)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func textLine(result Result) string {
+	v := result.Violation
+	line := fmt.Sprintf("%s:%d:%d: %s: %s", v.File, v.Line, v.Character, domain.SeverityName(result.Severity), result.Message)
+	if kind := v.Kind(); kind != "" {
+		line += fmt.Sprintf(" [%s]", kind)
+	}
+	return line
+}
+
+var _ Reporter = (*TextReporter)(nil)
@@ -0,0 +1,20 @@
+package report
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestTextReporter(t *testing.T) {
+	var b bytes.Buffer
+	if err := Write(&b, NewTextReporter(), testResults()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "packs/users/app/controllers/users_controller.rb:20:4: error: ::Book → packs/books (no dependency from packs/users) [dependency]\n" +
+		"packs/users/app/controllers/users_controller.rb:26:4: error: ::Book → packs/books (no dependency from packs/users) [dependency]\n" +
+		"packs/users/app/models/user.rb:8:4: warning: ::Books::Inventory → packs/books (private, referenced from packs/users) [privacy]\n"
+	if b.String() != want {
+		t.Errorf("unexpected output:\n--- got ---\n%s\n--- want ---\n%s", b.String(), want)
+	}
+}
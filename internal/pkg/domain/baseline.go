@@ -0,0 +1,173 @@
+package domain
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"path"
+	"slices"
+)
+
+// BaselineFile lists the violations known when it was created, at the root of the workspace.
+// Unlike package_todo.yml, it is owned by wpks-ls and does not change what packwerk reports.
+const BaselineFile = ".wpks-ls-baseline.json"
+
+// baselineVersion is the format of the baseline file written by this version.
+const baselineVersion = 1
+
+// IsBaselineFile reports whether the path or URI points at .wpks-ls-baseline.json.
+func IsBaselineFile(filePath string) bool {
+	return path.Base(filePath) == BaselineFile
+}
+
+// BaselineEntry is a known reference, counted as often as it occurs in the file.
+// The fields other than the fingerprint are there for reviewers of the file.
+type BaselineEntry struct {
+	Fingerprint       string `json:"fingerprint"`
+	File              string `json:"file"`
+	Type              string `json:"type"` // the violation kind, e.g. "dependency"
+	Constant          string `json:"constant,omitempty"`
+	ReferencedPackage string `json:"referencedPackage,omitempty"`
+	Count             int    `json:"count"`
+}
+
+// Baseline is a snapshot of the violations, identified by fingerprint rather than by line,
+// so that it still matches after lines are added or removed above them.
+type Baseline struct {
+	Entries []BaselineEntry // sorted by file, type, constant and referenced pack
+}
+
+type baselineFile struct {
+	Version    int             `json:"version"`
+	Violations []BaselineEntry `json:"violations"`
+}
+
+// NewBaseline takes a snapshot of the violations.
+func NewBaseline(violations []Violation) *Baseline {
+	entries := make(map[string]*BaselineEntry)
+	for _, v := range violations {
+		fingerprint := v.Fingerprint()
+		entry, ok := entries[fingerprint]
+		if !ok {
+			entry = &BaselineEntry{
+				Fingerprint:       fingerprint,
+				File:              v.File,
+				Type:              v.Kind(),
+				Constant:          v.Constant,
+				ReferencedPackage: v.ReferencedPackage,
+			}
+			entries[fingerprint] = entry
+		}
+		entry.Count++
+	}
+
+	baseline := &Baseline{Entries: make([]BaselineEntry, 0, len(entries))}
+	for _, entry := range entries {
+		baseline.Entries = append(baseline.Entries, *entry)
+	}
+	baseline.sort()
+	return baseline
+}
+
+// ParseBaseline reads the content of .wpks-ls-baseline.json.
+func ParseBaseline(text string) (*Baseline, error) {
+	var file baselineFile
+	if err := json.Unmarshal([]byte(text), &file); err != nil {
+		return nil, err
+	}
+	if file.Version != baselineVersion {
+		return nil, fmt.Errorf("unsupported version %d, expected %d", file.Version, baselineVersion)
+	}
+	baseline := &Baseline{Entries: make([]BaselineEntry, 0, len(file.Violations))}
+	for _, entry := range file.Violations {
+		if entry.Fingerprint == "" {
+			return nil, fmt.Errorf("an entry of %s has no fingerprint", entry.File)
+		}
+		// An entry edited by hand without a count still stands for one reference
+		entry.Count = max(entry.Count, 1)
+		baseline.Entries = append(baseline.Entries, entry)
+	}
+	baseline.sort()
+	return baseline, nil
+}
+
+// Text formats the baseline as written to .wpks-ls-baseline.json, ending with a newline.
+func (b *Baseline) Text() (string, error) {
+	data, err := json.MarshalIndent(baselineFile{Version: baselineVersion, Violations: b.Entries}, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data) + "\n", nil
+}
+
+// Len returns the number of violations in the baseline.
+func (b *Baseline) Len() int {
+	count := 0
+	for _, entry := range b.Entries {
+		count += entry.Count
+	}
+	return count
+}
+
+// Refresh drops the entries of the violations that were fixed since the baseline was created.
+// New violations are not added, so they keep being reported.
+func (b *Baseline) Refresh(violations []Violation) *Baseline {
+	current := NewBaseline(violations)
+	counts := make(map[string]int, len(current.Entries))
+	for _, entry := range current.Entries {
+		counts[entry.Fingerprint] = entry.Count
+	}
+
+	refreshed := &Baseline{Entries: make([]BaselineEntry, 0, len(b.Entries))}
+	for _, entry := range b.Entries {
+		entry.Count = min(entry.Count, counts[entry.Fingerprint])
+		if entry.Count > 0 {
+			refreshed.Entries = append(refreshed.Entries, entry)
+		}
+	}
+	return refreshed
+}
+
+// Known reports which of the violations are in the baseline. When a reference occurs more often
+// than the baseline counts, the ones nearest the top of the file are known and the others are new.
+func (b *Baseline) Known(violations []Violation) []bool {
+	known := make([]bool, len(violations))
+	if len(b.Entries) == 0 {
+		return known
+	}
+	counts := make(map[string]int, len(b.Entries))
+	for _, entry := range b.Entries {
+		counts[entry.Fingerprint] += entry.Count
+	}
+
+	occurrences := make(map[string][]int)
+	for i, v := range violations {
+		fingerprint := v.Fingerprint()
+		if counts[fingerprint] > 0 {
+			occurrences[fingerprint] = append(occurrences[fingerprint], i)
+		}
+	}
+	for fingerprint, indexes := range occurrences {
+		slices.SortStableFunc(indexes, func(i, j int) int {
+			return cmp.Or(
+				cmp.Compare(violations[i].Line, violations[j].Line),
+				cmp.Compare(violations[i].Character, violations[j].Character),
+			)
+		})
+		for _, i := range indexes[:min(len(indexes), counts[fingerprint])] {
+			known[i] = true
+		}
+	}
+	return known
+}
+
+func (b *Baseline) sort() {
+	slices.SortFunc(b.Entries, func(x, y BaselineEntry) int {
+		return cmp.Or(
+			cmp.Compare(x.File, y.File),
+			cmp.Compare(x.Type, y.Type),
+			cmp.Compare(x.Constant, y.Constant),
+			cmp.Compare(x.ReferencedPackage, y.ReferencedPackage),
+		)
+	})
+}
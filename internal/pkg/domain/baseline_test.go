@@ -0,0 +1,184 @@
+package domain
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func baselineViolation(file string, line uint32, constant string) Violation {
+	return Violation{
+		File:              file,
+		Line:              line,
+		Type:              "Dependency violation",
+		Constant:          constant,
+		ReferencedPackage: "packs/books",
+	}
+}
+
+func TestNewBaseline(t *testing.T) {
+	baseline := NewBaseline([]Violation{
+		baselineViolation("packs/users/app/models/user.rb", 12, "::Book"),
+		baselineViolation("packs/orders/app/models/order.rb", 3, "::Book"),
+		baselineViolation("packs/users/app/models/user.rb", 4, "::Book"),
+	})
+
+	want := []BaselineEntry{
+		{
+			Fingerprint:       baselineViolation("packs/orders/app/models/order.rb", 0, "::Book").Fingerprint(),
+			File:              "packs/orders/app/models/order.rb",
+			Type:              "dependency",
+			Constant:          "::Book",
+			ReferencedPackage: "packs/books",
+			Count:             1,
+		},
+		{
+			Fingerprint:       baselineViolation("packs/users/app/models/user.rb", 0, "::Book").Fingerprint(),
+			File:              "packs/users/app/models/user.rb",
+			Type:              "dependency",
+			Constant:          "::Book",
+			ReferencedPackage: "packs/books",
+			Count:             2,
+		},
+	}
+	if !reflect.DeepEqual(baseline.Entries, want) {
+		t.Errorf("want %+v, got %+v", want, baseline.Entries)
+	}
+	if baseline.Len() != 3 {
+		t.Errorf("want 3 violations, got %d", baseline.Len())
+	}
+}
+
+func TestBaseline_Text(t *testing.T) {
+	baseline := NewBaseline([]Violation{baselineViolation("packs/users/app/models/user.rb", 12, "::Book")})
+
+	text, err := baseline.Text()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(text, "{\n  \"version\": 1,\n  \"violations\": [\n") || !strings.HasSuffix(text, "}\n") {
+		t.Errorf("unexpected text:\n%s", text)
+	}
+
+	parsed, err := ParseBaseline(text)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(parsed, baseline) {
+		t.Errorf("want %+v, got %+v", baseline, parsed)
+	}
+}
+
+func TestParseBaseline(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		want    []BaselineEntry
+		wantErr string
+	}{
+		{
+			name: "count defaults to one",
+			text: `{"version": 1, "violations": [{"fingerprint": "abc", "file": "a.rb", "type": "privacy"}]}`,
+			want: []BaselineEntry{{Fingerprint: "abc", File: "a.rb", Type: "privacy", Count: 1}},
+		},
+		{
+			name: "empty",
+			text: `{"version": 1, "violations": []}`,
+			want: []BaselineEntry{},
+		},
+		{
+			name:    "other version",
+			text:    `{"version": 2, "violations": []}`,
+			wantErr: "unsupported version 2, expected 1",
+		},
+		{
+			name:    "missing fingerprint",
+			text:    `{"version": 1, "violations": [{"file": "a.rb"}]}`,
+			wantErr: "an entry of a.rb has no fingerprint",
+		},
+		{
+			name:    "not JSON",
+			text:    `violations: []`,
+			wantErr: "invalid character",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseBaseline(tt.text)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("want error %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got.Entries, tt.want) {
+				t.Errorf("want %+v, got %+v", tt.want, got.Entries)
+			}
+		})
+	}
+}
+
+func TestBaseline_Known(t *testing.T) {
+	const file = "packs/users/app/models/user.rb"
+	baseline := NewBaseline([]Violation{
+		baselineViolation(file, 4, "::Book"),
+		baselineViolation(file, 10, "::Shelf"),
+	})
+
+	// Lines were added above both references, and ::Book is referenced again below
+	violations := []Violation{
+		baselineViolation(file, 30, "::Book"),
+		baselineViolation(file, 8, "::Book"),
+		baselineViolation(file, 14, "::Shelf"),
+		baselineViolation(file, 20, "::Author"),
+		baselineViolation("packs/orders/app/models/order.rb", 4, "::Book"),
+	}
+	want := []bool{false, true, true, false, false}
+	if got := baseline.Known(violations); !reflect.DeepEqual(got, want) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+
+	if got := (&Baseline{}).Known(violations); !reflect.DeepEqual(got, make([]bool, len(violations))) {
+		t.Errorf("want nothing known without a baseline, got %v", got)
+	}
+}
+
+func TestBaseline_Refresh(t *testing.T) {
+	const file = "packs/users/app/models/user.rb"
+	baseline := NewBaseline([]Violation{
+		baselineViolation(file, 4, "::Book"),
+		baselineViolation(file, 6, "::Book"),
+		baselineViolation(file, 10, "::Shelf"),
+	})
+
+	// One ::Book and ::Shelf were fixed, and ::Author is new
+	refreshed := baseline.Refresh([]Violation{
+		baselineViolation(file, 5, "::Book"),
+		baselineViolation(file, 20, "::Author"),
+	})
+
+	want := []BaselineEntry{{
+		Fingerprint:       baselineViolation(file, 0, "::Book").Fingerprint(),
+		File:              file,
+		Type:              "dependency",
+		Constant:          "::Book",
+		ReferencedPackage: "packs/books",
+		Count:             1,
+	}}
+	if !reflect.DeepEqual(refreshed.Entries, want) {
+		t.Errorf("want %+v, got %+v", want, refreshed.Entries)
+	}
+	if baseline.Len() != 3 {
+		t.Errorf("want the baseline unchanged, got %d violations", baseline.Len())
+	}
+}
+
+func TestIsBaselineFile(t *testing.T) {
+	if !IsBaselineFile("file:///root/.wpks-ls-baseline.json") || IsBaselineFile("file:///root/baseline.json") {
+		t.Error("unexpected baseline file match")
+	}
+}
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"math"
+	"slices"
+)
+
+// LineRange is a range of 1-based lines, both ends included.
+type LineRange struct {
+	Start uint32
+	End   uint32
+}
+
+// ChangedLines are the lines changed since a base revision, by file path relative to the workspace root.
+type ChangedLines struct {
+	files map[string][]LineRange
+}
+
+func NewChangedLines() *ChangedLines {
+	return &ChangedLines{files: make(map[string][]LineRange)}
+}
+
+// Add marks the lines from start to end as changed.
+func (c *ChangedLines) Add(filePath string, start uint32, end uint32) {
+	c.files[filePath] = append(c.files[filePath], LineRange{Start: start, End: end})
+}
+
+// AddFile marks every line of the file as changed, e.g. for a file that is not tracked yet.
+func (c *ChangedLines) AddFile(filePath string) {
+	c.Add(filePath, 1, math.MaxUint32)
+}
+
+// Files returns the changed files, sorted.
+func (c *ChangedLines) Files() []string {
+	files := make([]string, 0, len(c.files))
+	for filePath := range c.files {
+		files = append(files, filePath)
+	}
+	slices.Sort(files)
+	return files
+}
+
+// Contains reports whether the line of the file was changed.
+func (c *ChangedLines) Contains(filePath string, line uint32) bool {
+	for _, r := range c.files[filePath] {
+		if r.Start <= line && line <= r.End {
+			return true
+		}
+	}
+	return false
+}
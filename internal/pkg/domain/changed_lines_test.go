@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestChangedLines(t *testing.T) {
+	changes := NewChangedLines()
+	changes.Add("packs/users/app/models/user.rb", 3, 3)
+	changes.Add("packs/users/app/models/user.rb", 10, 12)
+	changes.AddFile("packs/books/app/models/book.rb")
+
+	tests := []struct {
+		file string
+		line uint32
+		want bool
+	}{
+		{"packs/users/app/models/user.rb", 3, true},
+		{"packs/users/app/models/user.rb", 4, false},
+		{"packs/users/app/models/user.rb", 10, true},
+		{"packs/users/app/models/user.rb", 12, true},
+		{"packs/users/app/models/user.rb", 13, false},
+		{"packs/books/app/models/book.rb", 1000, true},
+		{"packs/orders/app/models/order.rb", 1, false},
+	}
+	for _, tt := range tests {
+		if got := changes.Contains(tt.file, tt.line); got != tt.want {
+			t.Errorf("%s:%d: want %v, got %v", tt.file, tt.line, tt.want, got)
+		}
+	}
+
+	want := []string{"packs/books/app/models/book.rb", "packs/users/app/models/user.rb"}
+	if got := changes.Files(); !reflect.DeepEqual(got, want) {
+		t.Errorf("want files %v, got %v", want, got)
+	}
+}
@@ -0,0 +1,8 @@
+package domain
+
+// CodeLens is a summary shown above a line, together with the locations it counts.
+type CodeLens struct {
+	Range     Range
+	Title     string
+	Locations []Location
+}
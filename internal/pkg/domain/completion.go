@@ -0,0 +1,16 @@
+package domain
+
+type CompletionItemKind int
+
+const (
+	CompletionKindPackage CompletionItemKind = iota + 1
+	CompletionKindKey
+	CompletionKindValue
+)
+
+type CompletionItem struct {
+	Label  string
+	Kind   CompletionItemKind
+	Detail string
+	Edit   TextEdit // replaces what has been typed so far
+}
@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// FindConstantDefinition returns the range of the name of the class, module or assignment
+// defining the constant in the Ruby source, e.g. `Inventory` in `class Books::Inventory`.
+func FindConstantDefinition(text string, constant string) (Range, bool) {
+	segments := strings.Split(strings.TrimPrefix(constant, "::"), "::")
+	name := segments[len(segments)-1]
+	if name == "" {
+		return Range{}, false
+	}
+	definition := regexp.MustCompile(`^\s*(?:(?:class|module)\s+(?:[A-Z]\w*::)*|)(` + regexp.QuoteMeta(name) + `)(?:\s*=[^=~]|\s*<|\s*$|\s*;|\s+#)`)
+
+	for i, line := range strings.Split(text, "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		m := definition.FindStringSubmatchIndex(line)
+		if m == nil {
+			continue
+		}
+		// The name alone on a line is not a definition
+		prefix := line[:m[2]]
+		if strings.TrimSpace(prefix) == "" && !strings.Contains(line[m[3]:], "=") {
+			continue
+		}
+		start := uint32(utf8.RuneCountInString(prefix))
+		return Range{
+			Start: Position{Line: uint32(i), Character: start},
+			End:   Position{Line: uint32(i), Character: start + uint32(utf8.RuneCountInString(name))},
+		}, true
+	}
+	return Range{}, false
+}
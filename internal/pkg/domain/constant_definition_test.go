@@ -0,0 +1,63 @@
+package domain
+
+import "testing"
+
+func TestFindConstantDefinition(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		constant string
+		want     Range
+		wantOK   bool
+	}{
+		{
+			name:     "class",
+			text:     "# frozen_string_literal: true\n\nclass Book < ApplicationRecord\nend\n",
+			constant: "::Book",
+			want:     Range{Start: Position{Line: 2, Character: 6}, End: Position{Line: 2, Character: 10}},
+			wantOK:   true,
+		},
+		{
+			name:     "nested module",
+			text:     "module Books\n  class Inventory\n  end\nend\n",
+			constant: "::Books::Inventory",
+			want:     Range{Start: Position{Line: 1, Character: 8}, End: Position{Line: 1, Character: 17}},
+			wantOK:   true,
+		},
+		{
+			name:     "compact style",
+			text:     "class Books::Inventory\nend\n",
+			constant: "::Books::Inventory",
+			want:     Range{Start: Position{Line: 0, Character: 13}, End: Position{Line: 0, Character: 22}},
+			wantOK:   true,
+		},
+		{
+			name:     "assignment",
+			text:     "module Books\n  LIMIT = 10\nend\n",
+			constant: "Books::LIMIT",
+			want:     Range{Start: Position{Line: 1, Character: 2}, End: Position{Line: 1, Character: 7}},
+			wantOK:   true,
+		},
+		{
+			name:     "reference is not a definition",
+			text:     "class User\n  Book\n  Book == other\nend\n",
+			constant: "::Book",
+			wantOK:   false,
+		},
+		{
+			name:     "longer name is not a match",
+			text:     "class BookShelf\nend\n",
+			constant: "::Book",
+			wantOK:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := FindConstantDefinition(tt.text, tt.constant)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("FindConstantDefinition() = %+v, %v; want %+v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
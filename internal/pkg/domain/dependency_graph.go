@@ -0,0 +1,107 @@
+package domain
+
+import "sort"
+
+// DependencyGraph maps a pack name to the packs it declares as dependencies.
+type DependencyGraph map[string][]string
+
+func (s *PackageSet) DependencyGraph() DependencyGraph {
+	graph := make(DependencyGraph, len(s.packages))
+	for name, pkg := range s.packages {
+		graph[name] = append([]string{}, pkg.Dependencies...)
+	}
+	return graph
+}
+
+// Cycles returns the strongly connected components made of more than one pack,
+// using Tarjan's algorithm. Packs and components are sorted for stable output.
+func (g DependencyGraph) Cycles() [][]string {
+	names := make([]string, 0, len(g))
+	for name := range g {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	index := 0
+	indices := make(map[string]int, len(g))
+	lowLinks := make(map[string]int, len(g))
+	onStack := make(map[string]bool, len(g))
+	var stack []string
+	var components [][]string
+
+	var connect func(name string)
+	connect = func(name string) {
+		indices[name] = index
+		lowLinks[name] = index
+		index++
+		stack = append(stack, name)
+		onStack[name] = true
+
+		for _, dependency := range g[name] {
+			if _, known := g[dependency]; !known {
+				continue
+			}
+			if _, visited := indices[dependency]; !visited {
+				connect(dependency)
+				lowLinks[name] = min(lowLinks[name], lowLinks[dependency])
+			} else if onStack[dependency] {
+				lowLinks[name] = min(lowLinks[name], indices[dependency])
+			}
+		}
+
+		if lowLinks[name] != indices[name] {
+			return
+		}
+		var component []string
+		for {
+			last := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[last] = false
+			component = append(component, last)
+			if last == name {
+				break
+			}
+		}
+		if len(component) > 1 {
+			sort.Strings(component)
+			components = append(components, component)
+		}
+	}
+
+	for _, name := range names {
+		if _, visited := indices[name]; !visited {
+			connect(name)
+		}
+	}
+
+	sort.Slice(components, func(i, j int) bool {
+		return components[i][0] < components[j][0]
+	})
+	return components
+}
+
+// CyclePath returns the shortest cycle going through the edge from -> to,
+// as [from, to, ..., from]. It returns nil when the edge is not part of a cycle.
+func (g DependencyGraph) CyclePath(from string, to string) []string {
+	previous := map[string]string{to: ""}
+	queue := []string{to}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if current == from {
+			path := []string{}
+			for node := from; node != ""; node = previous[node] {
+				path = append([]string{node}, path...)
+			}
+			return append([]string{from}, path...)
+		}
+		for _, dependency := range g[current] {
+			if _, seen := previous[dependency]; seen {
+				continue
+			}
+			previous[dependency] = current
+			queue = append(queue, dependency)
+		}
+	}
+	return nil
+}
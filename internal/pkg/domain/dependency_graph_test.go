@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDependencyGraph_Cycles(t *testing.T) {
+	tests := []struct {
+		name  string
+		graph DependencyGraph
+		want  [][]string
+	}{
+		{
+			name:  "no cycle",
+			graph: DependencyGraph{"a": {"b"}, "b": {"c"}, "c": nil},
+			want:  nil,
+		},
+		{
+			name:  "two packs",
+			graph: DependencyGraph{"a": {"b"}, "b": {"a"}},
+			want:  [][]string{{"a", "b"}},
+		},
+		{
+			name:  "three packs and a tail",
+			graph: DependencyGraph{"a": {"b"}, "b": {"c"}, "c": {"a", "d"}, "d": nil},
+			want:  [][]string{{"a", "b", "c"}},
+		},
+		{
+			name:  "two separate cycles",
+			graph: DependencyGraph{"a": {"b"}, "b": {"a"}, "x": {"y"}, "y": {"x"}},
+			want:  [][]string{{"a", "b"}, {"x", "y"}},
+		},
+		{
+			name:  "self dependency is not a cycle",
+			graph: DependencyGraph{"a": {"a"}},
+			want:  nil,
+		},
+		{
+			name:  "unknown dependency is ignored",
+			graph: DependencyGraph{"a": {"missing"}},
+			want:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.graph.Cycles(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("want %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestDependencyGraph_CyclePath(t *testing.T) {
+	graph := DependencyGraph{"a": {"b"}, "b": {"c", "a"}, "c": {"a"}, "d": {"a"}}
+
+	tests := []struct {
+		name string
+		from string
+		to   string
+		want []string
+	}{
+		{"shortest way back", "a", "b", []string{"a", "b", "a"}},
+		{"longer cycle", "c", "a", []string{"c", "a", "b", "c"}},
+		{"edge outside of a cycle", "d", "a", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := graph.CyclePath(tt.from, tt.to); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("want %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
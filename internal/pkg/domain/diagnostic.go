@@ -17,9 +17,36 @@ const (
 	SeverityHint    = 4
 )
 
-type Diagnostic struct {
-	Range    Range
-	Severity int32
-	Source   string
+// SeverityName returns the name of the severity as written in the settings, e.g. "warning".
+func SeverityName(severity int32) string {
+	switch severity {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	case SeverityInfo:
+		return "information"
+	case SeverityHint:
+		return "hint"
+	case SeverityIgnore:
+		return "ignore"
+	}
+	return "unknown"
+}
+
+type DiagnosticRelatedInformation struct {
+	Location Location
 	Message  string
 }
+
+type Diagnostic struct {
+	Range              Range
+	Severity           int32
+	Source             string
+	Code               string // e.g. the violation kind "dependency"
+	CodeHref           string // a page explaining the code
+	Message            string
+	RelatedInformation []DiagnosticRelatedInformation
+	Violation          *Violation // the violation reported, if any
+	Known              bool       // the violation is listed in the baseline
+}
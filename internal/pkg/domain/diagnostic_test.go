@@ -22,3 +22,15 @@ func TestDiagnosticStruct(t *testing.T) {
 		t.Errorf("unexpected diagnostic: %+v", d)
 	}
 }
+
+func TestSeverityName(t *testing.T) {
+	for _, severity := range []int32{SeverityError, SeverityWarning, SeverityInfo, SeverityHint, SeverityIgnore} {
+		got, ok := ParseSeverity(SeverityName(severity))
+		if !ok || got != severity {
+			t.Errorf("want %q to parse back to %d, got %d", SeverityName(severity), severity, got)
+		}
+	}
+	if got := SeverityName(0); got != "unknown" {
+		t.Errorf("want unknown, got %q", got)
+	}
+}
@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"path"
+	"strings"
+)
+
+// Document is a text document opened in the client.
+type Document struct {
+	URI  string
+	Text string
+}
+
+func NewDocument(uri string, text string) *Document {
+	return &Document{URI: uri, Text: text}
+}
+
+// Lines splits the text into lines without their line endings.
+func (d *Document) Lines() []string {
+	lines := strings.Split(d.Text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSuffix(line, "\r")
+	}
+	return lines
+}
+
+func (d *Document) IsPackageConfig() bool {
+	return path.Base(d.URI) == PackageConfigFile
+}
+
+func (d *Document) IsPackageTodo() bool {
+	return path.Base(d.URI) == PackageTodoFile
+}
+
+func (d *Document) IsPackwerkConfig() bool {
+	return path.Base(d.URI) == PackwerkConfigFile
+}
+
+func (d *Document) IsProjectSettings() bool {
+	return IsProjectSettingsFile(d.URI)
+}
+
+// IsConfigFile reports whether the path or URI points at package.yml or packwerk.yml.
+func IsConfigFile(filePath string) bool {
+	base := path.Base(filePath)
+	return base == PackageConfigFile || base == PackwerkConfigFile
+}
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDocument_Lines(t *testing.T) {
+	d := NewDocument("file:///root/package.yml", "a: 1\r\nb: 2\n")
+	want := []string{"a: 1", "b: 2", ""}
+	if got := d.Lines(); !reflect.DeepEqual(got, want) {
+		t.Errorf("want %q, got %q", want, got)
+	}
+}
+
+func TestDocument_IsPackageConfig(t *testing.T) {
+	tests := []struct {
+		uri  string
+		want bool
+	}{
+		{"file:///root/packs/books/package.yml", true},
+		{"file:///root/package.yml", true},
+		{"file:///root/packs/books/package_todo.yml", false},
+		{"file:///root/app/models/book.rb", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.uri, func(t *testing.T) {
+			if got := NewDocument(tt.uri, "").IsPackageConfig(); got != tt.want {
+				t.Errorf("want %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestDocument_IsPackageTodo(t *testing.T) {
+	if !NewDocument("file:///root/packs/books/package_todo.yml", "").IsPackageTodo() {
+		t.Error("expected package_todo.yml to be detected")
+	}
+	if NewDocument("file:///root/packs/books/package.yml", "").IsPackageTodo() {
+		t.Error("expected package.yml not to be a todo file")
+	}
+}
+
+func TestIsConfigFile(t *testing.T) {
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"file:///root/packs/books/package.yml", true},
+		{"file:///root/packwerk.yml", true},
+		{"packs/books/package_todo.yml", false},
+		{"app/models/book.rb", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := IsConfigFile(tt.path); got != tt.want {
+				t.Errorf("want %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
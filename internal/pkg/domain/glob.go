@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Glob is a compiled Ruby style glob pattern. It supports `**`, `*`, `?`,
+// `[...]` and `{a,b}` which is what packwerk.yml patterns use in practice.
+type Glob struct {
+	pattern string
+	regexp  *regexp.Regexp
+}
+
+func CompileGlob(pattern string) (*Glob, error) {
+	re, err := regexp.Compile("^" + globToRegexp(pattern) + "$")
+	if err != nil {
+		return nil, err
+	}
+	return &Glob{pattern: pattern, regexp: re}, nil
+}
+
+func (g *Glob) Pattern() string {
+	return g.pattern
+}
+
+// Match reports whether the slash separated relative path matches the pattern.
+func (g *Glob) Match(path string) bool {
+	return g.regexp.MatchString(path)
+}
+
+func globToRegexp(pattern string) string {
+	var b strings.Builder
+	depth := 0
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		switch {
+		case c == '*' && strings.HasPrefix(pattern[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 2
+		case c == '*' && strings.HasPrefix(pattern[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case c == '*':
+			b.WriteString("[^/]*")
+		case c == '?':
+			b.WriteString("[^/]")
+		case c == '[':
+			end := strings.IndexByte(pattern[i:], ']')
+			if end < 0 {
+				b.WriteString(regexp.QuoteMeta(string(c)))
+				continue
+			}
+			class := pattern[i+1 : i+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + class + "]")
+			i += end
+		case c == '{':
+			depth++
+			b.WriteString("(?:")
+		case c == '}' && depth > 0:
+			depth--
+			b.WriteString(")")
+		case c == ',' && depth > 0:
+			b.WriteString("|")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	return b.String()
+}
+
+// GlobSet matches a path against several patterns.
+type GlobSet []*Glob
+
+func CompileGlobSet(patterns []string) (GlobSet, error) {
+	set := make(GlobSet, 0, len(patterns))
+	for _, pattern := range patterns {
+		glob, err := CompileGlob(pattern)
+		if err != nil {
+			return nil, err
+		}
+		set = append(set, glob)
+	}
+	return set, nil
+}
+
+func (s GlobSet) Match(path string) bool {
+	for _, glob := range s {
+		if glob.Match(path) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,50 @@
+package domain
+
+import "testing"
+
+func TestGlob_Match(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		path    string
+		want    bool
+	}{
+		{"double star matches root file", "**/package.yml", "package.yml", true},
+		{"double star matches nested file", "**/package.yml", "packs/books/package.yml", true},
+		{"single star stays in segment", "packs/*/package.yml", "packs/books/package.yml", true},
+		{"single star does not cross segments", "packs/*/package.yml", "packs/books/sub/package.yml", false},
+		{"braces", "**/*.{rb,rake,erb}", "app/models/book.rb", true},
+		{"braces no match", "**/*.{rb,rake,erb}", "app/models/book.py", false},
+		{"exclude default", "{bin,node_modules,script,tmp,vendor}/**/*", "vendor/gems/foo.rb", true},
+		{"exclude default other dir", "{bin,node_modules,script,tmp,vendor}/**/*", "app/vendor.rb", false},
+		{"question mark", "app/?.rb", "app/a.rb", true},
+		{"character class", "app/[ab].rb", "app/c.rb", false},
+		{"negated character class", "app/[!ab].rb", "app/c.rb", true},
+		{"literal dot", "package.yml", "packageXyml", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			glob, err := CompileGlob(tt.pattern)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := glob.Match(tt.path); got != tt.want {
+				t.Errorf("Match(%q) with %q: want %v, got %v", tt.path, tt.pattern, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestGlobSet_Match(t *testing.T) {
+	set, err := CompileGlobSet([]string{"packs/*/package.yml", "components/*/package.yml"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !set.Match("components/auth/package.yml") {
+		t.Error("expected components/auth/package.yml to match")
+	}
+	if set.Match("lib/package.yml") {
+		t.Error("expected lib/package.yml not to match")
+	}
+}
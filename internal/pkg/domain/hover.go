@@ -0,0 +1,7 @@
+package domain
+
+// Hover is a Markdown explanation shown for a range of a document.
+type Hover struct {
+	Contents string
+	Range    Range
+}
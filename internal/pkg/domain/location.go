@@ -0,0 +1,20 @@
+package domain
+
+type Location struct {
+	URI   string
+	Range Range
+}
+
+// Contains reports whether the position lies inside the range, end inclusive.
+func (r Range) Contains(position Position) bool {
+	if position.Line < r.Start.Line || position.Line > r.End.Line {
+		return false
+	}
+	if position.Line == r.Start.Line && position.Character < r.Start.Character {
+		return false
+	}
+	if position.Line == r.End.Line && position.Character > r.End.Character {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,26 @@
+package domain
+
+import "testing"
+
+func TestRange_Contains(t *testing.T) {
+	r := Range{Start: Position{Line: 2, Character: 4}, End: Position{Line: 2, Character: 15}}
+	tests := []struct {
+		name     string
+		position Position
+		want     bool
+	}{
+		{"start", Position{Line: 2, Character: 4}, true},
+		{"middle", Position{Line: 2, Character: 10}, true},
+		{"end", Position{Line: 2, Character: 15}, true},
+		{"before start", Position{Line: 2, Character: 3}, false},
+		{"after end", Position{Line: 2, Character: 16}, false},
+		{"other line", Position{Line: 3, Character: 5}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := r.Contains(tt.position); got != tt.want {
+				t.Errorf("want %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
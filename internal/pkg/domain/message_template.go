@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Presets of the message template of the violation diagnostics.
+const (
+	MessageTemplateFull  = "full"  // the message of packwerk, joined into one line
+	MessageTemplateShort = "short" // e.g. "::Book → packs/books (no dependency from packs/users)"
+)
+
+var messageTemplatePresets = map[string]string{
+	MessageTemplateFull:  "{message}",
+	MessageTemplateShort: "{constant} → {referencedPackage} ({reason})",
+}
+
+var messagePlaceholderRegex = regexp.MustCompile(`\{(\w*)\}`)
+
+// messageFields are the placeholders a template can use.
+var messageFields = map[string]func(Violation) string{
+	"message":            func(v Violation) string { return v.Message },
+	"type":               func(v Violation) string { return v.Type },
+	"kind":               Violation.Kind,
+	"constant":           func(v Violation) string { return v.Constant },
+	"referencedPackage":  func(v Violation) string { return v.ReferencedPackage },
+	"referencingPackage": func(v Violation) string { return v.ReferencingPackage },
+	"reason":             Violation.Reason,
+}
+
+// MessageTemplate formats the messages of the violation diagnostics.
+type MessageTemplate struct {
+	text string
+}
+
+// CompileMessageTemplate accepts a preset name or a text with placeholders such as
+// "{constant} → {referencedPackage}". An empty template is the full message.
+func CompileMessageTemplate(template string) (MessageTemplate, error) {
+	if template == "" {
+		template = MessageTemplateFull
+	}
+	if preset, ok := messageTemplatePresets[template]; ok {
+		template = preset
+	}
+	for _, match := range messagePlaceholderRegex.FindAllStringSubmatch(template, -1) {
+		if _, ok := messageFields[match[1]]; !ok {
+			return MessageTemplate{}, fmt.Errorf("unknown placeholder '%s'. Expected %s", match[0], messagePlaceholders())
+		}
+	}
+	return MessageTemplate{text: template}, nil
+}
+
+// IsFull reports whether the template keeps the message of packwerk as is.
+func (t MessageTemplate) IsFull() bool {
+	return t.text == "" || t.text == messageTemplatePresets[MessageTemplateFull]
+}
+
+// Render formats the violation. Packwerk does not mention every detail in all of its messages,
+// so the full message is used when a placeholder has no value.
+func (t MessageTemplate) Render(v Violation) string {
+	if t.IsFull() {
+		return v.Message
+	}
+	missing := false
+	message := messagePlaceholderRegex.ReplaceAllStringFunc(t.text, func(placeholder string) string {
+		value := messageFields[placeholder[1:len(placeholder)-1]](v)
+		if value == "" {
+			missing = true
+		}
+		return value
+	})
+	if missing {
+		return v.Message
+	}
+	return message
+}
+
+func messagePlaceholders() string {
+	names := make([]string, 0, len(messageFields))
+	for name := range messageFields {
+		names = append(names, "{"+name+"}")
+	}
+	slices.Sort(names)
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMessageTemplate_Render(t *testing.T) {
+	violation := Violation{
+		Type:               "Dependency violation",
+		Message:            "Dependency violation: ::Book belongs to 'packs/books', but 'packs/users' does not specify a dependency on 'packs/books'. Are we missing an abstraction?",
+		Constant:           "::Book",
+		ReferencedPackage:  "packs/books",
+		ReferencingPackage: "packs/users",
+	}
+	tests := []struct {
+		name      string
+		template  string
+		violation Violation
+		want      string
+	}{
+		{"default", "", violation, violation.Message},
+		{"full", MessageTemplateFull, violation, violation.Message},
+		{"short", MessageTemplateShort, violation, "::Book → packs/books (no dependency from packs/users)"},
+		{"custom", "[{kind}] {constant} from {referencingPackage}", violation, "[dependency] ::Book from packs/users"},
+		{"without placeholders", "packwerk", violation, "packwerk"},
+		{"missing value", MessageTemplateShort, Violation{Type: "Dependency violation", Message: "unparsed"}, "unparsed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			template, err := CompileMessageTemplate(tt.template)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := template.Render(tt.violation); got != tt.want {
+				t.Errorf("want %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestCompileMessageTemplate_UnknownPlaceholder(t *testing.T) {
+	_, err := CompileMessageTemplate("{constant} in {pack}")
+	if err == nil || !strings.Contains(err.Error(), "unknown placeholder '{pack}'") {
+		t.Errorf("want an unknown placeholder error, got %v", err)
+	}
+}
+
+func TestMessageTemplate_IsFull(t *testing.T) {
+	for template, want := range map[string]bool{"": true, MessageTemplateFull: true, "{message}": true, MessageTemplateShort: false} {
+		compiled, _ := CompileMessageTemplate(template)
+		if got := compiled.IsFull(); got != want {
+			t.Errorf("%q: want %v, got %v", template, want, got)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package domain
+
+import "path"
+
+const (
+	RootPackageName   = "."
+	PackageConfigFile = "package.yml"
+	PackageTodoFile   = "package_todo.yml"
+	DefaultPublicPath = "app/public"
+)
+
+// Keys of package.yml
+const (
+	KeyEnforceDependencies  = "enforce_dependencies"
+	KeyEnforcePrivacy       = "enforce_privacy"
+	KeyEnforceVisibility    = "enforce_visibility"
+	KeyEnforceLayers        = "enforce_layers"
+	KeyEnforceFolderPrivacy = "enforce_folder_privacy"
+	KeyDependencies         = "dependencies"
+	KeyVisibleTo            = "visible_to"
+	KeyPublicPath           = "public_path"
+	KeyLayer                = "layer"
+	KeyOwner                = "owner"
+	KeyMetadata             = "metadata"
+)
+
+// PackageReferenceKeys are the package.yml keys whose items are pack names.
+var PackageReferenceKeys = []string{KeyDependencies, KeyVisibleTo}
+
+// Enforcement represents the value of an enforce_* flag in package.yml.
+// Packwerk accepts true, false and "strict".
+type Enforcement string
+
+const (
+	EnforcementUnset    Enforcement = ""
+	EnforcementDisabled Enforcement = "false"
+	EnforcementEnabled  Enforcement = "true"
+	EnforcementStrict   Enforcement = "strict"
+)
+
+// IsEnabled reports whether the checker is turned on.
+func (e Enforcement) IsEnabled() bool {
+	return e == EnforcementEnabled || e == EnforcementStrict
+}
+
+// IsValid reports whether the value is one that packwerk accepts.
+func (e Enforcement) IsValid() bool {
+	switch e {
+	case EnforcementUnset, EnforcementDisabled, EnforcementEnabled, EnforcementStrict:
+		return true
+	}
+	return false
+}
+
+// Package represents a pack defined by a package.yml file.
+type Package struct {
+	Name                string // path relative to the workspace root, "." for the root pack
+	Dependencies        []string
+	EnforceDependencies Enforcement
+	EnforcePrivacy      Enforcement
+	EnforceVisibility   Enforcement
+	EnforceLayers       Enforcement
+	PublicPath          string // as written in package.yml, empty when not set
+	VisibleTo           []string
+	Layer               string
+	Owner               string
+	Metadata            map[string]any
+}
+
+func NewPackage(name string) *Package {
+	return &Package{Name: name}
+}
+
+func (p *Package) IsRoot() bool {
+	return p.Name == RootPackageName
+}
+
+// ConfigPath returns the path of the package.yml relative to the workspace root.
+func (p *Package) ConfigPath() string {
+	return path.Join(p.Name, PackageConfigFile)
+}
+
+// TodoPath returns the path of the package_todo.yml relative to the workspace root.
+func (p *Package) TodoPath() string {
+	return path.Join(p.Name, PackageTodoFile)
+}
+
+// PublicDirectory returns the public folder relative to the workspace root.
+func (p *Package) PublicDirectory() string {
+	publicPath := p.PublicPath
+	if publicPath == "" {
+		publicPath = DefaultPublicPath
+	}
+	return path.Join(p.Name, publicPath)
+}
+
+func (p *Package) DependsOn(name string) bool {
+	for _, dependency := range p.Dependencies {
+		if dependency == name {
+			return true
+		}
+	}
+	return false
+}
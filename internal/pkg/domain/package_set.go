@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"path"
+	"sort"
+	"strings"
+)
+
+// PackageSet holds every pack of the workspace together with the packwerk config.
+// It is the registry the editor features look packs and public constants up in.
+type PackageSet struct {
+	Config          *PackwerkConfig
+	packages        map[string]*Package
+	publicConstants map[string][]PublicConstant
+}
+
+func NewPackageSet(config *PackwerkConfig, packages []*Package) *PackageSet {
+	set := &PackageSet{
+		Config:          config,
+		packages:        make(map[string]*Package, len(packages)+1),
+		publicConstants: make(map[string][]PublicConstant),
+	}
+	for _, pkg := range packages {
+		set.packages[pkg.Name] = pkg
+	}
+	if _, ok := set.packages[RootPackageName]; !ok {
+		set.packages[RootPackageName] = NewPackage(RootPackageName)
+	}
+	return set
+}
+
+func (s *PackageSet) Get(name string) (*Package, bool) {
+	pkg, ok := s.packages[name]
+	return pkg, ok
+}
+
+func (s *PackageSet) Root() *Package {
+	return s.packages[RootPackageName]
+}
+
+// All returns every pack sorted by name.
+func (s *PackageSet) All() []*Package {
+	packages := make([]*Package, 0, len(s.packages))
+	for _, pkg := range s.packages {
+		packages = append(packages, pkg)
+	}
+	sort.Slice(packages, func(i, j int) bool {
+		return packages[i].Name < packages[j].Name
+	})
+	return packages
+}
+
+// PackageOf returns the pack owning the file at the given path relative to the
+// workspace root. As in packwerk, the pack with the deepest directory containing
+// the file wins and the root pack is the fallback.
+func (s *PackageSet) PackageOf(filePath string) *Package {
+	dir := path.Dir(path.Clean(filePath))
+	for dir != "." && dir != "/" {
+		if pkg, ok := s.packages[dir]; ok {
+			return pkg
+		}
+		dir = path.Dir(dir)
+	}
+	return s.Root()
+}
+
+func (s *PackageSet) SetPublicConstants(name string, constants []PublicConstant) {
+	s.publicConstants[name] = constants
+}
+
+// PublicConstants returns the constants found in the public folder of the pack.
+func (s *PackageSet) PublicConstants(name string) []PublicConstant {
+	return s.publicConstants[name]
+}
+
+// IsPublicFile reports whether the file lies in the public folder of its pack.
+func (s *PackageSet) IsPublicFile(filePath string) bool {
+	return strings.HasPrefix(filePath, s.PackageOf(filePath).PublicDirectory()+"/")
+}
@@ -0,0 +1,84 @@
+package domain
+
+import "testing"
+
+func TestPackageSet_PackageOf(t *testing.T) {
+	set := NewPackageSet(NewPackwerkConfig(), []*Package{
+		NewPackage("packs/books"),
+		NewPackage("packs/books/reviews"),
+		NewPackage("packs/users"),
+	})
+
+	tests := []struct {
+		name     string
+		filePath string
+		want     string
+	}{
+		{"file in pack", "packs/books/app/models/book.rb", "packs/books"},
+		{"file in nested pack", "packs/books/reviews/app/models/review.rb", "packs/books/reviews"},
+		{"package.yml of a pack", "packs/users/package.yml", "packs/users"},
+		{"directory sharing a prefix", "packs/books_legacy/app/models/book.rb", "."},
+		{"file at root", "Gemfile", "."},
+		{"file outside packs", "lib/tasks/setup.rake", "."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := set.PackageOf(tt.filePath)
+			if got.Name != tt.want {
+				t.Errorf("want %q, got %q", tt.want, got.Name)
+			}
+		})
+	}
+}
+
+func TestPackageSet_All(t *testing.T) {
+	set := NewPackageSet(NewPackwerkConfig(), []*Package{NewPackage("packs/users"), NewPackage("packs/books")})
+	all := set.All()
+	want := []string{".", "packs/books", "packs/users"}
+	if len(all) != len(want) {
+		t.Fatalf("want %d packages, got %d", len(want), len(all))
+	}
+	for i, name := range want {
+		if all[i].Name != name {
+			t.Errorf("package %d: want %q, got %q", i, name, all[i].Name)
+		}
+	}
+}
+
+func TestPackageSet_IsPublicFile(t *testing.T) {
+	set := NewPackageSet(NewPackwerkConfig(), []*Package{
+		NewPackage("packs/books"),
+		{Name: "packs/users", PublicPath: "app/api"},
+	})
+
+	tests := []struct {
+		filePath string
+		want     bool
+	}{
+		{"packs/books/app/public/books/api.rb", true},
+		{"packs/books/app/models/book.rb", false},
+		{"packs/users/app/api/users.rb", true},
+		{"packs/users/app/public/users.rb", false},
+		{"app/public/search.rb", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filePath, func(t *testing.T) {
+			if got := set.IsPublicFile(tt.filePath); got != tt.want {
+				t.Errorf("want %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestPackageSet_PublicConstants(t *testing.T) {
+	set := NewPackageSet(NewPackwerkConfig(), []*Package{NewPackage("packs/books")})
+	constants := []PublicConstant{{Name: "Books::Api", File: "packs/books/app/public/books/api.rb"}}
+	set.SetPublicConstants("packs/books", constants)
+
+	if got := set.PublicConstants("packs/books"); len(got) != 1 || got[0].Name != "Books::Api" {
+		t.Errorf("unexpected public constants: %+v", got)
+	}
+	if got := set.PublicConstants("packs/users"); len(got) != 0 {
+		t.Errorf("expected no public constants, got %+v", got)
+	}
+}
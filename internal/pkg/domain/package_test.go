@@ -0,0 +1,69 @@
+package domain
+
+import "testing"
+
+func TestEnforcement(t *testing.T) {
+	tests := []struct {
+		value       Enforcement
+		wantEnabled bool
+		wantValid   bool
+	}{
+		{EnforcementUnset, false, true},
+		{EnforcementDisabled, false, true},
+		{EnforcementEnabled, true, true},
+		{EnforcementStrict, true, true},
+		{Enforcement("yes"), false, false},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.value), func(t *testing.T) {
+			if got := tt.value.IsEnabled(); got != tt.wantEnabled {
+				t.Errorf("IsEnabled: want %v, got %v", tt.wantEnabled, got)
+			}
+			if got := tt.value.IsValid(); got != tt.wantValid {
+				t.Errorf("IsValid: want %v, got %v", tt.wantValid, got)
+			}
+		})
+	}
+}
+
+func TestPackage_Paths(t *testing.T) {
+	tests := []struct {
+		name           string
+		pkg            *Package
+		wantConfigPath string
+		wantPublicDir  string
+	}{
+		{"root pack", NewPackage("."), "package.yml", "app/public"},
+		{"nested pack", NewPackage("packs/books"), "packs/books/package.yml", "packs/books/app/public"},
+		{"custom public path", &Package{Name: "packs/books", PublicPath: "app/api"}, "packs/books/package.yml", "packs/books/app/api"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.pkg.ConfigPath(); got != tt.wantConfigPath {
+				t.Errorf("ConfigPath: want %q, got %q", tt.wantConfigPath, got)
+			}
+			if got := tt.pkg.PublicDirectory(); got != tt.wantPublicDir {
+				t.Errorf("PublicDirectory: want %q, got %q", tt.wantPublicDir, got)
+			}
+		})
+	}
+}
+
+func TestPackage_TodoPath(t *testing.T) {
+	if got := NewPackage("packs/books").TodoPath(); got != "packs/books/package_todo.yml" {
+		t.Errorf("unexpected todo path: %q", got)
+	}
+	if got := NewPackage(".").TodoPath(); got != "package_todo.yml" {
+		t.Errorf("unexpected todo path for root pack: %q", got)
+	}
+}
+
+func TestPackage_DependsOn(t *testing.T) {
+	p := &Package{Name: "packs/users", Dependencies: []string{"packs/books"}}
+	if !p.DependsOn("packs/books") {
+		t.Error("expected packs/users to depend on packs/books")
+	}
+	if p.DependsOn("packs/orders") {
+		t.Error("expected packs/users not to depend on packs/orders")
+	}
+}
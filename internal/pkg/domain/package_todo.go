@@ -0,0 +1,36 @@
+package domain
+
+// TodoEntry is a file listed in package_todo.yml as a known reference to a constant of another pack.
+type TodoEntry struct {
+	ReferencedPackage string
+	Constant          string
+	ViolationTypes    []string
+	File              string
+	Range             Range // range of the file within package_todo.yml
+}
+
+// NewTodoEntries flattens a parsed package_todo.yml into one entry per listed file.
+func NewTodoEntries(root *YamlNode) []TodoEntry {
+	var entries []TodoEntry
+	if root == nil || root.Kind != YamlMapping {
+		return entries
+	}
+	for _, packNode := range root.Children {
+		for _, constantNode := range packNode.Children {
+			var violationTypes []string
+			for _, item := range constantNode.Get("violations").Items() {
+				violationTypes = append(violationTypes, item.Value)
+			}
+			for _, file := range constantNode.Get("files").Items() {
+				entries = append(entries, TodoEntry{
+					ReferencedPackage: packNode.Key,
+					Constant:          constantNode.Key,
+					ViolationTypes:    violationTypes,
+					File:              file.Value,
+					Range:             file.Range,
+				})
+			}
+		}
+	}
+	return entries
+}
@@ -0,0 +1,51 @@
+package domain
+
+const PackwerkConfigFile = "packwerk.yml"
+
+var (
+	DefaultInclude        = []string{"**/*.{rb,rake,erb}"}
+	DefaultExclude        = []string{"{bin,node_modules,script,tmp,vendor}/**/*"}
+	DefaultPackagePaths   = []string{"**/"}
+	DefaultCacheDirectory = "tmp/cache/packwerk"
+)
+
+// PackwerkConfig represents the settings read from packwerk.yml.
+type PackwerkConfig struct {
+	Include        []string
+	Exclude        []string
+	PackagePaths   []string
+	Cache          bool
+	CacheDirectory string
+	Layers         []string // ordered from the highest to the lowest layer
+}
+
+// NewPackwerkConfig returns a config filled with packwerk's defaults.
+func NewPackwerkConfig() *PackwerkConfig {
+	return &PackwerkConfig{
+		Include:        append([]string{}, DefaultInclude...),
+		Exclude:        append([]string{}, DefaultExclude...),
+		PackagePaths:   append([]string{}, DefaultPackagePaths...),
+		Cache:          true,
+		CacheDirectory: DefaultCacheDirectory,
+	}
+}
+
+// IsCheckedFile reports whether packwerk checks the file, according to include and exclude.
+func (c *PackwerkConfig) IsCheckedFile(filePath string) bool {
+	include, err := CompileGlobSet(c.Include)
+	if err != nil || !include.Match(filePath) {
+		return false
+	}
+	exclude, err := CompileGlobSet(c.Exclude)
+	return err == nil && !exclude.Match(filePath)
+}
+
+// LayerIndex returns the position of the layer in the configured order, or -1.
+func (c *PackwerkConfig) LayerIndex(layer string) int {
+	for i, l := range c.Layers {
+		if l == layer {
+			return i
+		}
+	}
+	return -1
+}
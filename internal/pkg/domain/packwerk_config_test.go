@@ -0,0 +1,23 @@
+package domain
+
+import "testing"
+
+func TestPackwerkConfig_IsCheckedFile(t *testing.T) {
+	config := NewPackwerkConfig()
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"packs/users/app/models/user.rb", true},
+		{"lib/tasks/import.rake", true},
+		{"app/views/users/show.html.erb", true},
+		{"packs/users/package.yml", false},
+		{"vendor/bundle/gems/rack/lib/rack.rb", false},
+		{"README.md", false},
+	}
+	for _, tt := range tests {
+		if got := config.IsCheckedFile(tt.path); got != tt.want {
+			t.Errorf("%s: want %v, got %v", tt.path, tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,279 @@
+package domain
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ProjectSettingsFile holds the settings of wpks-ls shared by a team, at the root of the workspace.
+const ProjectSettingsFile = ".wpks-ls.yml"
+
+// IsProjectSettingsFile reports whether the path or URI points at .wpks-ls.yml.
+func IsProjectSettingsFile(filePath string) bool {
+	return path.Base(filePath) == ProjectSettingsFile
+}
+
+// SettingsError is an invalid entry of .wpks-ls.yml.
+type SettingsError struct {
+	Range   Range
+	Message string
+}
+
+// ApplyProjectSettings overrides the settings with the entries of .wpks-ls.yml.
+// Invalid entries are reported and skipped, so a typo does not discard the rest of the file.
+func ApplyProjectSettings(root *YamlNode, settings Settings) (Settings, []SettingsError) {
+	p := &projectSettingsParser{settings: settings}
+	if root.Kind != YamlMapping {
+		p.fail(root.Range, "Expected a mapping of settings.")
+		return p.settings, p.errors
+	}
+
+	for _, node := range root.Children {
+		switch node.Key {
+		case "checker":
+			p.parseChecker(node)
+		case "severity":
+			p.settings = parseSeverity(p, node, p.settings)
+		case "overrides":
+			p.parseOverrides(node)
+		case "exclude_paths":
+			p.parseExcludePaths(node)
+		case "diagnostics":
+			p.parseDiagnostics(node)
+		case "cache":
+			p.parseCache(node)
+		default:
+			p.fail(node.KeyRange, "Unknown setting '%s'. Expected checker, severity, overrides, exclude_paths, diagnostics or cache.", node.Key)
+		}
+	}
+	return p.settings, p.errors
+}
+
+type projectSettingsParser struct {
+	settings Settings
+	errors   []SettingsError
+}
+
+func (p *projectSettingsParser) fail(r Range, format string, args ...any) {
+	p.errors = append(p.errors, SettingsError{Range: r, Message: fmt.Sprintf(format, args...)})
+}
+
+// mapping returns the entries of node, reporting an error when it is not a mapping.
+func (p *projectSettingsParser) mapping(node *YamlNode) []*YamlNode {
+	if node.Kind != YamlMapping {
+		p.fail(node.Range, "Invalid '%s'. Expected a mapping.", node.Key)
+		return nil
+	}
+	return node.Children
+}
+
+func (p *projectSettingsParser) parseChecker(node *YamlNode) {
+	for _, child := range p.mapping(node) {
+		switch child.Key {
+		case "command":
+			// A command line such as "bundle exec packwerk", or its words
+			var command []string
+			if child.Kind == YamlScalar {
+				command = strings.Fields(child.Value)
+			} else if words, ok := p.strings(child); ok {
+				command = words
+			} else {
+				continue
+			}
+			if len(command) == 0 {
+				p.fail(child.Range, "Invalid 'checker.command'. Expected a command.")
+				continue
+			}
+			p.settings.CheckerCommand = command
+		case "timeout":
+			if seconds, ok := p.number(child, "checker.timeout", 0); ok {
+				p.settings.Timeout = time.Duration(seconds * float64(time.Second))
+			}
+		case "concurrency":
+			if concurrency, ok := p.integer(child, "checker.concurrency"); ok {
+				p.settings.Concurrency = concurrency
+			}
+		default:
+			p.fail(child.KeyRange, "Unknown setting 'checker.%s'. Expected command, timeout or concurrency.", child.Key)
+		}
+	}
+}
+
+// severitySetter is implemented by Settings and SeverityOverride.
+type severitySetter[T any] interface {
+	WithSeverity(kind string, severity int32) T
+}
+
+// parseSeverity accepts a severity for every violation, or one per violation kind.
+func parseSeverity[T severitySetter[T]](p *projectSettingsParser, node *YamlNode, target T) T {
+	if node.Kind == YamlScalar {
+		if severity, ok := p.severity(node); ok {
+			target = target.WithSeverity("default", severity)
+		}
+		return target
+	}
+	for _, child := range p.mapping(node) {
+		if child.Key != "default" && !slices.Contains(ViolationKinds, child.Key) {
+			p.fail(child.KeyRange, "Unknown violation type '%s'. Expected default or one of %v.", child.Key, ViolationKinds)
+			continue
+		}
+		if severity, ok := p.severity(child); ok {
+			target = target.WithSeverity(child.Key, severity)
+		}
+	}
+	return target
+}
+
+// parseOverrides reads a list of paths with the severities applying to them.
+func (p *projectSettingsParser) parseOverrides(node *YamlNode) {
+	if node.Kind != YamlSequence {
+		p.fail(node.Range, "Invalid 'overrides'. Expected a list.")
+		return
+	}
+	overrides := make([]SeverityOverride, 0, len(node.Children))
+	for _, item := range node.Children {
+		if item.Kind != YamlMapping {
+			p.fail(item.Range, "Invalid item in 'overrides'. Expected paths and a severity.")
+			continue
+		}
+		override := SeverityOverride{}
+		for _, child := range item.Children {
+			switch child.Key {
+			case "paths":
+				if patterns, ok := p.globs(child); ok {
+					override.Paths = patterns
+				}
+			case "severity":
+				override = parseSeverity(p, child, override)
+			default:
+				p.fail(child.KeyRange, "Unknown setting 'overrides.%s'. Expected paths or severity.", child.Key)
+			}
+		}
+		if len(override.Paths) == 0 {
+			p.fail(item.Range, "Invalid item in 'overrides'. Expected paths.")
+			continue
+		}
+		overrides = append(overrides, override)
+	}
+	if len(overrides) > 0 {
+		p.settings.Overrides = overrides
+	}
+}
+
+func (p *projectSettingsParser) parseExcludePaths(node *YamlNode) {
+	if patterns, ok := p.globs(node); ok {
+		p.settings.ExcludePaths = patterns
+	}
+}
+
+func (p *projectSettingsParser) parseDiagnostics(node *YamlNode) {
+	for _, child := range p.mapping(node) {
+		switch child.Key {
+		case "batch_size":
+			if size, ok := p.integer(child, "diagnostics.batch_size"); ok {
+				p.settings.BatchSize = size
+			}
+		case "batch_delay":
+			if milliseconds, ok := p.number(child, "diagnostics.batch_delay", 0); ok {
+				p.settings.BatchDelay = time.Duration(milliseconds * float64(time.Millisecond))
+			}
+		case "message_template":
+			if child.Kind != YamlScalar {
+				p.fail(child.Range, "Invalid 'diagnostics.message_template'. Expected full, short or a text with placeholders such as {constant}.")
+				continue
+			}
+			if _, err := CompileMessageTemplate(child.Value); err != nil {
+				p.fail(child.Range, "Invalid 'diagnostics.message_template': %s.", err)
+				continue
+			}
+			p.settings.MessageTemplate = child.Value
+		case "diff_base":
+			if child.Kind != YamlScalar {
+				p.fail(child.Range, "Invalid 'diagnostics.diff_base'. Expected a git revision such as HEAD.")
+				continue
+			}
+			p.settings.DiffBase = child.Value
+		case "baseline_severity":
+			if severity, ok := p.severity(child); ok {
+				p.settings.BaselineSeverity = severity
+			}
+		default:
+			p.fail(child.KeyRange, "Unknown setting 'diagnostics.%s'. Expected batch_size, batch_delay, message_template, diff_base or baseline_severity.", child.Key)
+		}
+	}
+}
+
+func (p *projectSettingsParser) parseCache(node *YamlNode) {
+	for _, child := range p.mapping(node) {
+		switch child.Key {
+		case "directory":
+			if child.Kind != YamlScalar || child.Value == "" {
+				p.fail(child.Range, "Invalid 'cache.directory'. Expected a path relative to the project root.")
+				continue
+			}
+			p.settings.CacheDirectory = child.Value
+		default:
+			p.fail(child.KeyRange, "Unknown setting 'cache.%s'. Expected directory.", child.Key)
+		}
+	}
+}
+
+func (p *projectSettingsParser) severity(node *YamlNode) (int32, bool) {
+	severity, ok := ParseSeverity(node.ScalarValue())
+	if !ok {
+		p.fail(node.Range, "Invalid severity '%s'. Expected error, warning, information, hint or ignore.", node.ScalarValue())
+	}
+	return severity, ok
+}
+
+func (p *projectSettingsParser) strings(node *YamlNode) ([]string, bool) {
+	if node.Kind != YamlSequence {
+		p.fail(node.Range, "Invalid '%s'. Expected a list.", node.Key)
+		return nil, false
+	}
+	values := make([]string, 0, len(node.Children))
+	for _, item := range node.Children {
+		if item.Kind != YamlScalar {
+			p.fail(item.Range, "Invalid item in '%s'. Expected a string.", node.Key)
+			return nil, false
+		}
+		values = append(values, item.Value)
+	}
+	return values, true
+}
+
+func (p *projectSettingsParser) globs(node *YamlNode) ([]string, bool) {
+	patterns, ok := p.strings(node)
+	if !ok {
+		return nil, false
+	}
+	for i, pattern := range patterns {
+		if _, err := CompileGlob(pattern); err != nil {
+			p.fail(node.Children[i].Range, "Invalid glob '%s' in '%s'.", pattern, node.Key)
+			return nil, false
+		}
+	}
+	return patterns, true
+}
+
+func (p *projectSettingsParser) number(node *YamlNode, name string, minimum float64) (float64, bool) {
+	value, err := strconv.ParseFloat(node.ScalarValue(), 64)
+	if err != nil || value < minimum {
+		p.fail(node.Range, "Invalid '%s' value '%s'. Expected a number of at least %g.", name, node.ScalarValue(), minimum)
+		return 0, false
+	}
+	return value, true
+}
+
+func (p *projectSettingsParser) integer(node *YamlNode, name string) (int, bool) {
+	value, err := strconv.Atoi(node.ScalarValue())
+	if err != nil || value < 1 {
+		p.fail(node.Range, "Invalid '%s' value '%s'. Expected a positive integer.", name, node.ScalarValue())
+		return 0, false
+	}
+	return value, true
+}
@@ -0,0 +1,164 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func scalarNode(key, value string) *YamlNode {
+	return &YamlNode{Kind: YamlScalar, Key: key, Value: value}
+}
+
+func mappingNode(key string, children ...*YamlNode) *YamlNode {
+	return &YamlNode{Kind: YamlMapping, Key: key, Children: children}
+}
+
+func sequenceNode(key string, values ...string) *YamlNode {
+	node := &YamlNode{Kind: YamlSequence, Key: key}
+	for _, value := range values {
+		node.Children = append(node.Children, scalarNode("", value))
+	}
+	return node
+}
+
+func TestApplyProjectSettings(t *testing.T) {
+	root := mappingNode("",
+		mappingNode("checker",
+			scalarNode("command", "bundle exec packwerk"),
+			scalarNode("timeout", "30"),
+			scalarNode("concurrency", "2"),
+		),
+		mappingNode("severity",
+			scalarNode("default", "warning"),
+			scalarNode("privacy", "hint"),
+		),
+		sequenceNode("exclude_paths", "spec/**"),
+		&YamlNode{Kind: YamlSequence, Key: "overrides", Children: []*YamlNode{
+			mappingNode("", sequenceNode("paths", "test/**"), scalarNode("severity", "hint")),
+			mappingNode("", sequenceNode("paths", "packs/legacy/**"), mappingNode("severity", scalarNode("layer", "ignore"))),
+		}},
+		mappingNode("diagnostics",
+			scalarNode("batch_size", "20"),
+			scalarNode("batch_delay", "250"),
+			scalarNode("message_template", "short"),
+			scalarNode("diff_base", "origin/main"),
+			scalarNode("baseline_severity", "ignore"),
+		),
+		mappingNode("cache", scalarNode("directory", "tmp/packwerk")),
+	)
+
+	got, errs := ApplyProjectSettings(root, NewSettings())
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors: %+v", errs)
+	}
+	want := Settings{
+		CheckerCommand: []string{"bundle", "exec", "packwerk"},
+		Severity:       SeverityWarning,
+		SeverityByKind: map[string]int32{"privacy": SeverityHint},
+		Overrides: []SeverityOverride{
+			{Paths: []string{"test/**"}, Severity: SeverityHint},
+			{Paths: []string{"packs/legacy/**"}, SeverityByKind: map[string]int32{"layer": SeverityIgnore}},
+		},
+		ExcludePaths:     []string{"spec/**"},
+		Timeout:          30 * time.Second,
+		Concurrency:      2,
+		BatchSize:        20,
+		BatchDelay:       250 * time.Millisecond,
+		CacheDirectory:   "tmp/packwerk",
+		MessageTemplate:  "short",
+		DiffBase:         "origin/main",
+		BaselineSeverity: SeverityIgnore,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %+v, got %+v", want, got)
+	}
+	if got.SeverityOf("privacy") != SeverityHint || got.SeverityOf("dependency") != SeverityWarning {
+		t.Errorf("unexpected severities: %+v", got)
+	}
+}
+
+func TestApplyProjectSettings_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		root    *YamlNode
+		wantMsg string
+	}{
+		{
+			name:    "not a mapping",
+			root:    sequenceNode("", "a"),
+			wantMsg: "Expected a mapping of settings.",
+		},
+		{
+			name:    "unknown setting",
+			root:    mappingNode("", scalarNode("severty", "error")),
+			wantMsg: "Unknown setting 'severty'. Expected checker, severity, overrides, exclude_paths, diagnostics or cache.",
+		},
+		{
+			name:    "invalid severity",
+			root:    mappingNode("", scalarNode("severity", "fatal")),
+			wantMsg: "Invalid severity 'fatal'. Expected error, warning, information, hint or ignore.",
+		},
+		{
+			name:    "unknown violation type",
+			root:    mappingNode("", mappingNode("severity", scalarNode("dependencies", "error"))),
+			wantMsg: "Unknown violation type 'dependencies'. Expected default or one of [dependency privacy layer visibility folder_privacy].",
+		},
+		{
+			name:    "invalid timeout",
+			root:    mappingNode("", mappingNode("checker", scalarNode("timeout", "soon"))),
+			wantMsg: "Invalid 'checker.timeout' value 'soon'. Expected a number of at least 0.",
+		},
+		{
+			name:    "invalid concurrency",
+			root:    mappingNode("", mappingNode("checker", scalarNode("concurrency", "0"))),
+			wantMsg: "Invalid 'checker.concurrency' value '0'. Expected a positive integer.",
+		},
+		{
+			name:    "exclude_paths is not a list",
+			root:    mappingNode("", scalarNode("exclude_paths", "spec/**")),
+			wantMsg: "Invalid 'exclude_paths'. Expected a list.",
+		},
+		{
+			name:    "invalid glob",
+			root:    mappingNode("", sequenceNode("exclude_paths", "spec/{models")),
+			wantMsg: "Invalid glob 'spec/{models' in 'exclude_paths'.",
+		},
+		{
+			name:    "override without paths",
+			root:    mappingNode("", &YamlNode{Kind: YamlSequence, Key: "overrides", Children: []*YamlNode{mappingNode("", scalarNode("severity", "hint"))}}),
+			wantMsg: "Invalid item in 'overrides'. Expected paths.",
+		},
+		{
+			name:    "unknown placeholder",
+			root:    mappingNode("", mappingNode("diagnostics", scalarNode("message_template", "{constant} in {pack}"))),
+			wantMsg: "Invalid 'diagnostics.message_template': unknown placeholder '{pack}'. Expected {constant}, {kind}, {message}, {reason}, {referencedPackage}, {referencingPackage}, {type}.",
+		},
+		{
+			name:    "unknown nested setting",
+			root:    mappingNode("", mappingNode("diagnostics", scalarNode("delay", "10"))),
+			wantMsg: "Unknown setting 'diagnostics.delay'. Expected batch_size, batch_delay, message_template, diff_base or baseline_severity.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, errs := ApplyProjectSettings(tt.root, NewSettings())
+			if len(errs) != 1 {
+				t.Fatalf("want 1 error, got %+v", errs)
+			}
+			if errs[0].Message != tt.wantMsg {
+				t.Errorf("want message %q, got %q", tt.wantMsg, errs[0].Message)
+			}
+			if !reflect.DeepEqual(got, NewSettings()) {
+				t.Errorf("want invalid entries to be skipped, got %+v", got)
+			}
+		})
+	}
+}
+
+func TestIsProjectSettingsFile(t *testing.T) {
+	if !IsProjectSettingsFile("file:///root/.wpks-ls.yml") || IsProjectSettingsFile("file:///root/packwerk.yml") {
+		t.Error("unexpected result of IsProjectSettingsFile")
+	}
+}
@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"path"
+	"strings"
+)
+
+// PublicConstant is a constant exposed through the public folder of a pack.
+type PublicConstant struct {
+	Name string // e.g. "Books::Api"
+	File string // path relative to the workspace root
+}
+
+// NewPublicConstant derives the constant name from the file path the way
+// Zeitwerk does, treating the public folder as an autoload root.
+func NewPublicConstant(publicDirectory string, file string) PublicConstant {
+	relPath := strings.TrimPrefix(file, publicDirectory+"/")
+	relPath = strings.TrimSuffix(relPath, path.Ext(relPath))
+
+	segments := strings.Split(relPath, "/")
+	for i, segment := range segments {
+		segments[i] = Camelize(segment)
+	}
+	return PublicConstant{Name: strings.Join(segments, "::"), File: file}
+}
+
+// Camelize turns snake_case into CamelCase.
+func Camelize(s string) string {
+	var b strings.Builder
+	for _, word := range strings.Split(s, "_") {
+		if word == "" {
+			continue
+		}
+		b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	return b.String()
+}
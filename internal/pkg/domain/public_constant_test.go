@@ -0,0 +1,38 @@
+package domain
+
+import "testing"
+
+func TestNewPublicConstant(t *testing.T) {
+	tests := []struct {
+		name            string
+		publicDirectory string
+		file            string
+		want            string
+	}{
+		{"top level file", "packs/books/app/public", "packs/books/app/public/book_api.rb", "BookApi"},
+		{"namespaced file", "packs/books/app/public", "packs/books/app/public/books/api_client.rb", "Books::ApiClient"},
+		{"root pack", "app/public", "app/public/search.rb", "Search"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewPublicConstant(tt.publicDirectory, tt.file)
+			if got.Name != tt.want || got.File != tt.file {
+				t.Errorf("want %q from %q, got %+v", tt.want, tt.file, got)
+			}
+		})
+	}
+}
+
+func TestCamelize(t *testing.T) {
+	tests := map[string]string{
+		"book":         "Book",
+		"book_api":     "BookApi",
+		"__private__":  "Private",
+		"already_Done": "AlreadyDone",
+	}
+	for input, want := range tests {
+		if got := Camelize(input); got != want {
+			t.Errorf("Camelize(%q): want %q, got %q", input, want, got)
+		}
+	}
+}
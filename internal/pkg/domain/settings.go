@@ -0,0 +1,176 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SeverityIgnore hides the violations instead of reporting them with a severity.
+const SeverityIgnore = -1
+
+// Settings are the preferences of .wpks-ls.yml and of the client.
+// Unlike the workspace, they can change while the server is running.
+type Settings struct {
+	CheckerCommand   []string           // replaces the fallback order of packwerk commands when set
+	Severity         int32              // severity of the violation diagnostics
+	SeverityByKind   map[string]int32   // overrides Severity for a violation kind, e.g. "privacy"
+	Overrides        []SeverityOverride // other severities for some paths, later ones taking precedence
+	ExcludePaths     []string           // globs of the files whose violations are not reported
+	Timeout          time.Duration      // zero lets packwerk run as long as it needs
+	Concurrency      int                // packwerk processes a check of several files is split into
+	BatchSize        int                // opened files checked together by one packwerk process
+	BatchDelay       time.Duration      // how long changes are collected before they are checked
+	CacheDirectory   string             // overrides cache_directory of packwerk.yml when set
+	MessageTemplate  string             // a preset such as "short", or a text with placeholders; empty is the full message
+	DiffBase         string             // when set, only violations on the lines changed since this git revision are reported
+	BaselineSeverity int32              // severity of the violations listed in .wpks-ls-baseline.json, at most
+}
+
+// SeverityOverride changes the severities of the violations in the files matching Paths,
+// e.g. to relax the rules under spec/.
+type SeverityOverride struct {
+	Paths          []string
+	Severity       int32 // zero keeps the severity of the settings
+	SeverityByKind map[string]int32
+}
+
+func NewSettings() Settings {
+	return Settings{
+		Severity:         SeverityError,
+		Concurrency:      1,
+		BatchSize:        10,
+		BatchDelay:       100 * time.Millisecond,
+		BaselineSeverity: SeverityHint,
+	}
+}
+
+// SeverityOf returns the severity of the violations of the kind, ignoring the overrides.
+func (s Settings) SeverityOf(kind string) int32 {
+	if severity, ok := s.SeverityByKind[kind]; ok {
+		return severity
+	}
+	return s.Severity
+}
+
+// WithSeverity returns a copy of the settings where the kind has the severity.
+// The kind "default" sets the severity of every kind without an override.
+func (s Settings) WithSeverity(kind string, severity int32) Settings {
+	if kind == "default" {
+		s.Severity = severity
+		return s
+	}
+	s.SeverityByKind = withSeverity(s.SeverityByKind, kind, severity)
+	return s
+}
+
+// WithSeverity returns a copy of the override where the kind has the severity.
+func (o SeverityOverride) WithSeverity(kind string, severity int32) SeverityOverride {
+	if kind == "default" {
+		o.Severity = severity
+		return o
+	}
+	o.SeverityByKind = withSeverity(o.SeverityByKind, kind, severity)
+	return o
+}
+
+func withSeverity(byKind map[string]int32, kind string, severity int32) map[string]int32 {
+	updated := make(map[string]int32, len(byKind)+1)
+	for k, v := range byKind {
+		updated[k] = v
+	}
+	updated[kind] = severity
+	return updated
+}
+
+// ParseSeverity converts the name of a severity as written in the settings.
+func ParseSeverity(name string) (int32, bool) {
+	switch strings.ToLower(name) {
+	case "error":
+		return SeverityError, true
+	case "warning":
+		return SeverityWarning, true
+	case "information", "info":
+		return SeverityInfo, true
+	case "hint":
+		return SeverityHint, true
+	case "ignore":
+		return SeverityIgnore, true
+	}
+	return 0, false
+}
+
+// IsValidSeverity reports whether the severity can be used in the settings.
+func IsValidSeverity(severity int32) bool {
+	return severity == SeverityIgnore || (severity >= SeverityError && severity <= SeverityHint)
+}
+
+// ViolationRules decide how the violations are reported. They are compiled from the settings.
+type ViolationRules struct {
+	settings  Settings
+	excludes  GlobSet
+	overrides []GlobSet // the paths of each override
+	message   MessageTemplate
+}
+
+// CompileRules compiles the globs and the message template of the settings.
+func (s Settings) CompileRules() (*ViolationRules, error) {
+	excludes, err := CompileGlobSet(s.ExcludePaths)
+	if err != nil {
+		return nil, fmt.Errorf("invalid exclude path: %w", err)
+	}
+	overrides := make([]GlobSet, 0, len(s.Overrides))
+	for _, override := range s.Overrides {
+		paths, err := CompileGlobSet(override.Paths)
+		if err != nil {
+			return nil, fmt.Errorf("invalid override path: %w", err)
+		}
+		overrides = append(overrides, paths)
+	}
+	message, err := CompileMessageTemplate(s.MessageTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid message template: %w", err)
+	}
+	return &ViolationRules{settings: s, excludes: excludes, overrides: overrides, message: message}, nil
+}
+
+// Message formats the message of the diagnostic of the violation.
+func (r *ViolationRules) Message(v Violation) string {
+	return r.message.Render(v)
+}
+
+// ShortensMessages reports whether the diagnostics leave out part of the messages of packwerk.
+func (r *ViolationRules) ShortensMessages() bool {
+	return !r.message.IsFull()
+}
+
+// Severity returns the severity of the violation, or false when it is not reported.
+func (r *ViolationRules) Severity(v Violation) (int32, bool) {
+	if r.excludes.Match(v.File) {
+		return 0, false
+	}
+
+	kind := v.Kind()
+	severity := r.settings.SeverityOf(kind)
+	for i, override := range r.settings.Overrides {
+		if !r.overrides[i].Match(v.File) {
+			continue
+		}
+		if s, ok := override.SeverityByKind[kind]; ok {
+			severity = s
+		} else if override.Severity != 0 {
+			severity = override.Severity
+		}
+	}
+	return severity, severity != SeverityIgnore
+}
+
+// KnownSeverity lowers the severity of a violation listed in the baseline,
+// or returns false when known violations are not reported.
+func (r *ViolationRules) KnownSeverity(severity int32) (int32, bool) {
+	if r.settings.BaselineSeverity == SeverityIgnore {
+		return 0, false
+	}
+	// Larger values are less severe
+	return max(severity, r.settings.BaselineSeverity), true
+}
@@ -0,0 +1,126 @@
+package domain
+
+import "testing"
+
+func TestParseSeverity(t *testing.T) {
+	tests := []struct {
+		name   string
+		want   int32
+		wantOK bool
+	}{
+		{name: "error", want: SeverityError, wantOK: true},
+		{name: "Warning", want: SeverityWarning, wantOK: true},
+		{name: "info", want: SeverityInfo, wantOK: true},
+		{name: "information", want: SeverityInfo, wantOK: true},
+		{name: "hint", want: SeverityHint, wantOK: true},
+		{name: "ignore", want: SeverityIgnore, wantOK: true},
+		{name: "fatal", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParseSeverity(tt.name)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("ParseSeverity(%q) = %d, %v; want %d, %v", tt.name, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestSettings_WithSeverity(t *testing.T) {
+	settings := NewSettings()
+	updated := settings.WithSeverity("privacy", SeverityWarning).WithSeverity("default", SeverityHint)
+
+	if settings.SeverityOf("privacy") != SeverityError {
+		t.Error("expected the original settings to be left untouched")
+	}
+	if updated.SeverityOf("privacy") != SeverityWarning || updated.SeverityOf("layer") != SeverityHint {
+		t.Errorf("unexpected severities: %+v", updated)
+	}
+}
+
+func TestViolationRules_Severity(t *testing.T) {
+	settings := NewSettings().
+		WithSeverity("privacy", SeverityWarning).
+		WithSeverity("layer", SeverityIgnore)
+	settings.ExcludePaths = []string{"vendor/**"}
+	settings.Overrides = []SeverityOverride{
+		{Paths: []string{"spec/**"}, Severity: SeverityHint},
+		{Paths: []string{"spec/system/**"}, SeverityByKind: map[string]int32{"dependency": SeverityIgnore}},
+		{Paths: []string{"packs/legacy/**"}, SeverityByKind: map[string]int32{"layer": SeverityInfo}},
+	}
+
+	rules, err := settings.CompileRules()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		file      string
+		kind      string
+		want      int32
+		wantShown bool
+	}{
+		{name: "default severity", file: "app/models/user.rb", kind: "Dependency violation", want: SeverityError, wantShown: true},
+		{name: "severity of the kind", file: "app/models/user.rb", kind: "Privacy violation", want: SeverityWarning, wantShown: true},
+		{name: "ignored kind", file: "app/models/user.rb", kind: "Layer violation", wantShown: false},
+		{name: "excluded path", file: "vendor/gems/foo.rb", kind: "Dependency violation", wantShown: false},
+		{name: "override of every kind", file: "spec/models/user_spec.rb", kind: "Privacy violation", want: SeverityHint, wantShown: true},
+		{name: "later override of a kind", file: "spec/system/login_spec.rb", kind: "Dependency violation", wantShown: false},
+		{name: "earlier override still applies to other kinds", file: "spec/system/login_spec.rb", kind: "Privacy violation", want: SeverityHint, wantShown: true},
+		{name: "override showing an ignored kind", file: "packs/legacy/app/a.rb", kind: "Layer violation", want: SeverityInfo, wantShown: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, shown := rules.Severity(Violation{File: tt.file, Type: tt.kind})
+			if shown != tt.wantShown || (shown && got != tt.want) {
+				t.Errorf("Severity() = %d, %v; want %d, %v", got, shown, tt.want, tt.wantShown)
+			}
+		})
+	}
+}
+
+func TestViolationRules_KnownSeverity(t *testing.T) {
+	tests := []struct {
+		name             string
+		baselineSeverity int32
+		severity         int32
+		want             int32
+		wantShown        bool
+	}{
+		{name: "lowered to the baseline severity", baselineSeverity: SeverityHint, severity: SeverityError, want: SeverityHint, wantShown: true},
+		{name: "never raised", baselineSeverity: SeverityWarning, severity: SeverityHint, want: SeverityHint, wantShown: true},
+		{name: "hidden", baselineSeverity: SeverityIgnore, severity: SeverityError, wantShown: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := NewSettings()
+			settings.BaselineSeverity = tt.baselineSeverity
+			rules, err := settings.CompileRules()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got, shown := rules.KnownSeverity(tt.severity)
+			if shown != tt.wantShown || (shown && got != tt.want) {
+				t.Errorf("KnownSeverity() = %d, %v; want %d, %v", got, shown, tt.want, tt.wantShown)
+			}
+		})
+	}
+}
+
+func TestSettings_CompileRules_InvalidGlob(t *testing.T) {
+	settings := NewSettings()
+	settings.ExcludePaths = []string{"spec/{models"}
+	if _, err := settings.CompileRules(); err == nil {
+		t.Error("expected an error for an invalid exclude path")
+	}
+
+	settings = NewSettings()
+	settings.Overrides = []SeverityOverride{{Paths: []string{"spec/{models"}, Severity: SeverityHint}}
+	if _, err := settings.CompileRules(); err == nil {
+		t.Error("expected an error for an invalid override path")
+	}
+}
@@ -0,0 +1,30 @@
+package domain
+
+type SymbolKind int
+
+const (
+	SymbolKindPackage SymbolKind = iota + 1
+	SymbolKindConstant
+	SymbolKindNamespace
+	SymbolKindKey
+	SymbolKindArray
+	SymbolKindFile
+)
+
+// Symbol is a named location that can be searched across the workspace.
+type Symbol struct {
+	Name          string
+	Kind          SymbolKind
+	Location      Location
+	ContainerName string
+}
+
+// DocumentSymbol is an entry of the outline of a document.
+type DocumentSymbol struct {
+	Name           string
+	Detail         string
+	Kind           SymbolKind
+	Range          Range // the whole entry including its children
+	SelectionRange Range // the name of the entry
+	Children       []DocumentSymbol
+}
@@ -0,0 +1,22 @@
+package domain
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// TextEdit replaces the text in Range with NewText.
+type TextEdit struct {
+	Range   Range
+	NewText string
+}
+
+// WholeRange returns the range spanning the whole text.
+func WholeRange(text string) Range {
+	lines := strings.Split(text, "\n")
+	last := lines[len(lines)-1]
+	return Range{
+		Start: Position{Line: 0, Character: 0},
+		End:   Position{Line: uint32(len(lines) - 1), Character: uint32(utf8.RuneCountInString(last))},
+	}
+}
@@ -0,0 +1,20 @@
+package domain
+
+import "testing"
+
+func TestWholeRange(t *testing.T) {
+	tests := []struct {
+		text string
+		want Position
+	}{
+		{"", Position{Line: 0, Character: 0}},
+		{"packs/books:\n", Position{Line: 1, Character: 0}},
+		{"a\nbé", Position{Line: 1, Character: 2}},
+	}
+	for _, tt := range tests {
+		got := WholeRange(tt.text)
+		if got.Start != (Position{}) || got.End != tt.want {
+			t.Errorf("%q: want end %+v, got %+v", tt.text, tt.want, got)
+		}
+	}
+}
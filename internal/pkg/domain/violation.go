@@ -1,9 +1,70 @@
 package domain
 
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
 type Violation struct {
 	File      string
 	Line      uint32
 	Character uint32
 	Message   string
 	Type      string // e.g. "Dependency violation"
+
+	// Parsed from the message when packwerk mentions them
+	Constant           string // e.g. "::Book"
+	ReferencedPackage  string // the pack the constant belongs to
+	ReferencingPackage string // the pack of the file making the reference
+
+	Details        string // the paragraphs after the message, e.g. "Inference details: ..."
+	HelpURL        string // the troubleshooting page packwerk links to
+	DefinitionFile string // the file packwerk infers the constant is defined in
+}
+
+// ViolationKinds are the violation types packwerk and packwerk-extensions report.
+var ViolationKinds = []string{"dependency", "privacy", "layer", "visibility", "folder_privacy"}
+
+// Kind returns the violation type as written in package_todo.yml, e.g. "dependency".
+func (v Violation) Kind() string {
+	kind := strings.ToLower(strings.TrimSuffix(v.Type, " violation"))
+	return strings.ReplaceAll(kind, " ", "_")
+}
+
+// Range converts the 1-based line reported by packwerk into a 0-based range of one character.
+func (v Violation) Range() Range {
+	return Range{
+		Start: Position{Line: v.Line - 1, Character: v.Character},
+		End:   Position{Line: v.Line - 1, Character: v.Character + 1},
+	}
+}
+
+// Reason summarizes why the reference is a violation, e.g. "no dependency from packs/users".
+// It is empty when packwerk does not mention the referencing pack.
+func (v Violation) Reason() string {
+	if v.ReferencingPackage == "" {
+		return ""
+	}
+	switch v.Kind() {
+	case "dependency":
+		return fmt.Sprintf("no dependency from %s", v.ReferencingPackage)
+	case "privacy":
+		return fmt.Sprintf("private, referenced from %s", v.ReferencingPackage)
+	case "visibility":
+		return fmt.Sprintf("not visible to %s", v.ReferencingPackage)
+	case "layer":
+		return fmt.Sprintf("layer not accessible from %s", v.ReferencingPackage)
+	case "folder_privacy":
+		return fmt.Sprintf("private folder, referenced from %s", v.ReferencingPackage)
+	}
+	return fmt.Sprintf("%s from %s", strings.ReplaceAll(v.Kind(), "_", " "), v.ReferencingPackage)
+}
+
+// Fingerprint identifies the reference independently of its position, so it survives lines
+// being added above it: the file, the violation type, the constant and the referenced pack.
+func (v Violation) Fingerprint() string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{v.File, v.Kind(), v.Constant, v.ReferencedPackage}, "\x00")))
+	return hex.EncodeToString(sum[:])
 }
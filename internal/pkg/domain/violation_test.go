@@ -14,3 +14,71 @@ func TestViolationStruct(t *testing.T) {
 		t.Errorf("unexpected violation: %+v", v)
 	}
 }
+
+func TestViolation_Kind(t *testing.T) {
+	tests := []struct {
+		violationType string
+		want          string
+	}{
+		{"Dependency violation", "dependency"},
+		{"Privacy violation", "privacy"},
+		{"Layer violation", "layer"},
+		{"Folder Privacy violation", "folder_privacy"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := (Violation{Type: tt.violationType}).Kind(); got != tt.want {
+			t.Errorf("%q: want %q, got %q", tt.violationType, tt.want, got)
+		}
+	}
+}
+
+func TestViolation_Range(t *testing.T) {
+	v := Violation{File: "foo.rb", Line: 20, Character: 4}
+	want := Range{Start: Position{Line: 19, Character: 4}, End: Position{Line: 19, Character: 5}}
+	if got := v.Range(); got != want {
+		t.Errorf("want %+v, got %+v", want, got)
+	}
+}
+
+func TestViolation_Reason(t *testing.T) {
+	tests := []struct {
+		violationType      string
+		referencingPackage string
+		want               string
+	}{
+		{"Dependency violation", "packs/users", "no dependency from packs/users"},
+		{"Privacy violation", "packs/users", "private, referenced from packs/users"},
+		{"Visibility violation", "packs/users", "not visible to packs/users"},
+		{"Layer violation", "packs/users", "layer not accessible from packs/users"},
+		{"Folder Privacy violation", "packs/users", "private folder, referenced from packs/users"},
+		{"Custom violation", "packs/users", "custom from packs/users"},
+		{"Dependency violation", "", ""},
+	}
+	for _, tt := range tests {
+		v := Violation{Type: tt.violationType, ReferencingPackage: tt.referencingPackage}
+		if got := v.Reason(); got != tt.want {
+			t.Errorf("%q from %q: want %q, got %q", tt.violationType, tt.referencingPackage, tt.want, got)
+		}
+	}
+}
+
+func TestViolation_Fingerprint(t *testing.T) {
+	v := Violation{File: "packs/users/app/models/user.rb", Line: 3, Character: 4, Type: "Privacy violation", Constant: "::Books::Inventory", ReferencedPackage: "packs/books"}
+
+	moved := v
+	moved.Line, moved.Character = 10, 8
+	if v.Fingerprint() != moved.Fingerprint() {
+		t.Error("want the fingerprint to ignore the position")
+	}
+	for name, other := range map[string]Violation{
+		"file":     {File: "packs/users/app/models/admin.rb", Type: v.Type, Constant: v.Constant, ReferencedPackage: v.ReferencedPackage},
+		"type":     {File: v.File, Type: "Dependency violation", Constant: v.Constant, ReferencedPackage: v.ReferencedPackage},
+		"constant": {File: v.File, Type: v.Type, Constant: "::Books::Shelf", ReferencedPackage: v.ReferencedPackage},
+		"pack":     {File: v.File, Type: v.Type, Constant: v.Constant, ReferencedPackage: "packs/library"},
+	} {
+		if other.Fingerprint() == v.Fingerprint() {
+			t.Errorf("want another %s to change the fingerprint", name)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package domain
+
+type FileChangeKind int
+
+const (
+	FileChangeModify FileChangeKind = iota
+	FileChangeCreate
+	FileChangeDelete
+)
+
+// FileChange describes how a file differs between two snapshots.
+type FileChange struct {
+	URI    string
+	Kind   FileChangeKind
+	Before string
+	After  string
+}
+
+// NewFileChange compares two snapshots of a file. It returns false when nothing changed.
+func NewFileChange(uri string, before string, existedBefore bool, after string, existsAfter bool) (FileChange, bool) {
+	change := FileChange{URI: uri, Before: before, After: after}
+	switch {
+	case !existedBefore && !existsAfter:
+		return change, false
+	case !existedBefore:
+		change.Kind = FileChangeCreate
+	case !existsAfter:
+		change.Kind = FileChangeDelete
+	case before == after:
+		return change, false
+	default:
+		change.Kind = FileChangeModify
+	}
+	return change, true
+}
+
+// WorkspaceEdit is a set of file changes proposed to the user, who approves them in the client.
+type WorkspaceEdit struct {
+	Label   string
+	Changes []FileChange
+}
+
+func (e *WorkspaceEdit) IsEmpty() bool {
+	return e == nil || len(e.Changes) == 0
+}
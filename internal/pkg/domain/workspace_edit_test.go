@@ -0,0 +1,45 @@
+package domain
+
+import "testing"
+
+func TestNewFileChange(t *testing.T) {
+	tests := []struct {
+		name          string
+		before        string
+		existedBefore bool
+		after         string
+		existsAfter   bool
+		wantKind      FileChangeKind
+		wantChanged   bool
+	}{
+		{"unchanged", "a", true, "a", true, 0, false},
+		{"never existed", "", false, "", false, 0, false},
+		{"modified", "a", true, "b", true, FileChangeModify, true},
+		{"created", "", false, "b", true, FileChangeCreate, true},
+		{"deleted", "a", true, "", false, FileChangeDelete, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, changed := NewFileChange("file:///root/package_todo.yml", tt.before, tt.existedBefore, tt.after, tt.existsAfter)
+			if changed != tt.wantChanged {
+				t.Fatalf("want changed %v, got %v", tt.wantChanged, changed)
+			}
+			if changed && got.Kind != tt.wantKind {
+				t.Errorf("want kind %v, got %v", tt.wantKind, got.Kind)
+			}
+		})
+	}
+}
+
+func TestWorkspaceEdit_IsEmpty(t *testing.T) {
+	var edit *WorkspaceEdit
+	if !edit.IsEmpty() {
+		t.Error("expected a nil edit to be empty")
+	}
+	if !(&WorkspaceEdit{}).IsEmpty() {
+		t.Error("expected an edit without changes to be empty")
+	}
+	if (&WorkspaceEdit{Changes: []FileChange{{URI: "file:///root/package_todo.yml"}}}).IsEmpty() {
+		t.Error("expected an edit with changes not to be empty")
+	}
+}
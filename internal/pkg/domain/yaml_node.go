@@ -0,0 +1,102 @@
+package domain
+
+import "fmt"
+
+// YamlSyntaxError is returned when a YAML document cannot be parsed.
+type YamlSyntaxError struct {
+	Line    uint32 // 0-based
+	Message string
+}
+
+func (e *YamlSyntaxError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line+1, e.Message)
+}
+
+type YamlNodeKind int
+
+const (
+	YamlScalar YamlNodeKind = iota
+	YamlMapping
+	YamlSequence
+)
+
+// YamlNode is a YAML node annotated with source positions.
+// Mapping entries are stored as children carrying their Key and KeyRange.
+type YamlNode struct {
+	Kind     YamlNodeKind
+	Key      string
+	KeyRange Range
+	Value    string // only set for scalars
+	Range    Range  // range of the value
+	Children []*YamlNode
+}
+
+// Get returns the child of a mapping with the given key.
+func (n *YamlNode) Get(key string) *YamlNode {
+	if n == nil || n.Kind != YamlMapping {
+		return nil
+	}
+	for _, child := range n.Children {
+		if child.Key == key {
+			return child
+		}
+	}
+	return nil
+}
+
+// ScalarValue returns the value of a scalar node, or an empty string.
+func (n *YamlNode) ScalarValue() string {
+	if n == nil || n.Kind != YamlScalar {
+		return ""
+	}
+	return n.Value
+}
+
+// Items returns the scalar items of a sequence. A single scalar counts as one item.
+func (n *YamlNode) Items() []*YamlNode {
+	if n == nil {
+		return nil
+	}
+	switch n.Kind {
+	case YamlSequence:
+		items := make([]*YamlNode, 0, len(n.Children))
+		for _, child := range n.Children {
+			if child.Kind == YamlScalar {
+				items = append(items, child)
+			}
+		}
+		return items
+	case YamlScalar:
+		if n.Value == "" {
+			return nil
+		}
+		return []*YamlNode{n}
+	}
+	return nil
+}
+
+// ItemAt returns the scalar item of a sequence under the position.
+func (n *YamlNode) ItemAt(position Position) *YamlNode {
+	for _, item := range n.Items() {
+		if item.Range.Contains(position) {
+			return item
+		}
+	}
+	return nil
+}
+
+// Extent returns the range spanning the key of the node and all of its descendants.
+func (n *YamlNode) Extent() Range {
+	extent := n.Range
+	if n.Key != "" {
+		extent.Start = n.KeyRange.Start
+	}
+	for _, child := range n.Children {
+		childExtent := child.Extent()
+		if childExtent.End.Line > extent.End.Line ||
+			(childExtent.End.Line == extent.End.Line && childExtent.End.Character > extent.End.Character) {
+			extent.End = childExtent.End
+		}
+	}
+	return extent
+}
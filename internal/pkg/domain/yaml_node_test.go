@@ -0,0 +1,63 @@
+package domain
+
+import "testing"
+
+func TestYamlNode_Get(t *testing.T) {
+	root := &YamlNode{Kind: YamlMapping, Children: []*YamlNode{
+		{Kind: YamlScalar, Key: "layer", Value: "product"},
+	}}
+	if got := root.Get("layer"); got == nil || got.Value != "product" {
+		t.Errorf("unexpected node: %+v", got)
+	}
+	if got := root.Get("missing"); got != nil {
+		t.Errorf("expected nil, got %+v", got)
+	}
+	var nilNode *YamlNode
+	if got := nilNode.Get("layer"); got != nil {
+		t.Errorf("expected nil from nil node, got %+v", got)
+	}
+}
+
+func TestYamlNode_ItemAt(t *testing.T) {
+	sequence := &YamlNode{Kind: YamlSequence, Children: []*YamlNode{
+		{Kind: YamlScalar, Value: "packs/books", Range: Range{Start: Position{Line: 2, Character: 4}, End: Position{Line: 2, Character: 15}}},
+		{Kind: YamlScalar, Value: "packs/users", Range: Range{Start: Position{Line: 3, Character: 4}, End: Position{Line: 3, Character: 15}}},
+	}}
+
+	if got := sequence.ItemAt(Position{Line: 3, Character: 8}); got == nil || got.Value != "packs/users" {
+		t.Errorf("unexpected item: %+v", got)
+	}
+	if got := sequence.ItemAt(Position{Line: 3, Character: 1}); got != nil {
+		t.Errorf("expected no item, got %+v", got)
+	}
+}
+
+func TestYamlNode_ScalarValue(t *testing.T) {
+	var nilNode *YamlNode
+	if got := nilNode.ScalarValue(); got != "" {
+		t.Errorf("expected empty value from nil node, got %q", got)
+	}
+	if got := (&YamlNode{Kind: YamlSequence}).ScalarValue(); got != "" {
+		t.Errorf("expected empty value from sequence, got %q", got)
+	}
+	if got := (&YamlNode{Kind: YamlScalar, Value: "strict"}).ScalarValue(); got != "strict" {
+		t.Errorf("want %q, got %q", "strict", got)
+	}
+}
+
+func TestYamlNode_Extent(t *testing.T) {
+	node := &YamlNode{
+		Kind:     YamlSequence,
+		Key:      "dependencies",
+		KeyRange: Range{Start: Position{Line: 1, Character: 0}, End: Position{Line: 1, Character: 12}},
+		Range:    Range{Start: Position{Line: 2, Character: 2}, End: Position{Line: 2, Character: 2}},
+		Children: []*YamlNode{
+			{Kind: YamlScalar, Value: "packs/books", Range: Range{Start: Position{Line: 2, Character: 4}, End: Position{Line: 2, Character: 15}}},
+			{Kind: YamlScalar, Value: "packs/users", Range: Range{Start: Position{Line: 3, Character: 4}, End: Position{Line: 3, Character: 15}}},
+		},
+	}
+	want := Range{Start: Position{Line: 1, Character: 0}, End: Position{Line: 3, Character: 15}}
+	if got := node.Extent(); got != want {
+		t.Errorf("want %+v, got %+v", want, got)
+	}
+}
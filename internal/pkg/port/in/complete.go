@@ -0,0 +1,7 @@
+package in
+
+import "github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+
+type Complete interface {
+	Complete(uri string, position domain.Position) ([]domain.CompletionItem, error)
+}
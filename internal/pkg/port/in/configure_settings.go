@@ -0,0 +1,10 @@
+package in
+
+import "github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+
+type ConfigureSettings interface {
+	// ProjectSettings reads .wpks-ls.yml. Invalid entries are skipped and returned as errors.
+	ProjectSettings() (domain.Settings, []domain.SettingsError, error)
+	// Configure validates and applies the settings.
+	Configure(settings domain.Settings) error
+}
@@ -9,4 +9,8 @@ import (
 type DiagnoseFile interface {
 	Diagnose(context context.Context, uris ...string) (map[string][]domain.Diagnostic, error)
 	DiagnoseAll(context context.Context) (map[string][]domain.Diagnostic, error)
+	// DiagnoseChanged checks the files changed since the diff base of the settings.
+	DiagnoseChanged(context context.Context) (map[string][]domain.Diagnostic, error)
+	// Rebuild converts the violations of the latest checks again with the current settings.
+	Rebuild() (map[string][]domain.Diagnostic, error)
 }
@@ -0,0 +1,7 @@
+package in
+
+import "github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+
+type FindDefinition interface {
+	Definition(uri string, position domain.Position) ([]domain.Location, error)
+}
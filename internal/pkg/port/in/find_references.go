@@ -0,0 +1,7 @@
+package in
+
+import "github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+
+type FindReferences interface {
+	References(uri string, position domain.Position, includeDeclaration bool) ([]domain.Location, error)
+}
@@ -0,0 +1,6 @@
+package in
+
+type LoadPackages interface {
+	Load() error
+	Refresh(uris ...string) error
+}
@@ -0,0 +1,15 @@
+package in
+
+import "context"
+
+type ManageBaseline interface {
+	// CreateBaseline checks the whole project and lists every reported violation in .wpks-ls-baseline.json.
+	// It returns the number of violations listed.
+	CreateBaseline(context context.Context) (int, error)
+	// RefreshBaseline checks the whole project and drops the violations fixed since the baseline was created.
+	// It returns the number of violations still listed.
+	RefreshBaseline(context context.Context) (int, error)
+	// ValidateBaseline reports why .wpks-ls-baseline.json cannot be read.
+	// The diagnostics report every violation as new meanwhile.
+	ValidateBaseline() error
+}
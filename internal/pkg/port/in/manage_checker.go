@@ -0,0 +1,14 @@
+package in
+
+import (
+	"context"
+
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+)
+
+type ManageChecker interface {
+	// UpdateTodo returns the package_todo.yml changes for the pack owning uri, or for every pack when uri is empty.
+	UpdateTodo(context context.Context, uri string) (*domain.WorkspaceEdit, error)
+	ClearCache() error
+	Restart() error
+}
@@ -0,0 +1,7 @@
+package in
+
+import "github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+
+type ResolvePackage interface {
+	Resolve(uri string) (*domain.Package, error)
+}
@@ -0,0 +1,8 @@
+package in
+
+import "github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+
+type ShowHover interface {
+	// Hover returns nil when there is nothing to explain at the position.
+	Hover(uri string, position domain.Position) (*domain.Hover, error)
+}
@@ -0,0 +1,7 @@
+package in
+
+type SyncDocument interface {
+	Open(uri string, text string) error
+	Change(uri string, text string) error
+	Close(uri string) error
+}
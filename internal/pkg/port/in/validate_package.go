@@ -0,0 +1,12 @@
+package in
+
+import (
+	"context"
+
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+)
+
+type ValidatePackage interface {
+	Validate(context context.Context, uris ...string) (map[string][]domain.Diagnostic, error)
+	ValidateAll(context context.Context) (map[string][]domain.Diagnostic, error)
+}
@@ -0,0 +1,11 @@
+package out
+
+import "github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+
+type DocumentRepository interface {
+	Save(document *domain.Document) error
+	GetDocument(uri string) (*domain.Document, error)
+	// GetDocuments returns the open documents sorted by URI.
+	GetDocuments() ([]*domain.Document, error)
+	Delete(uri string) error
+}
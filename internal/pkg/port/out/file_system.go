@@ -0,0 +1,9 @@
+package out
+
+// FileSystem reads and writes files by absolute path.
+type FileSystem interface {
+	// ReadFile returns the content of the file and whether it exists.
+	ReadFile(path string) (string, bool, error)
+	WriteFile(path string, text string) error
+	RemoveFile(path string) error
+}
@@ -0,0 +1,8 @@
+package out
+
+import "github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+
+type PackageRepository interface {
+	Save(packageSet *domain.PackageSet) error
+	GetPackageSet() (*domain.PackageSet, error)
+}
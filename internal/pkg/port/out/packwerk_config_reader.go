@@ -0,0 +1,11 @@
+package out
+
+import "github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+
+type PackwerkConfigReader interface {
+	ReadConfig(rootPath string) (*domain.PackwerkConfig, error)
+	ReadPackages(rootPath string, config *domain.PackwerkConfig) ([]*domain.Package, error)
+	ReadPublicFiles(rootPath string, pkg *domain.Package) ([]string, error)
+	ReadPackageNode(rootPath string, pkg *domain.Package) (*domain.YamlNode, error)
+	ReadPackageTodo(rootPath string, pkg *domain.Package) ([]domain.TodoEntry, error)
+}
@@ -9,4 +9,12 @@ import (
 type PackwerkRunner interface {
 	RunCheck(context context.Context, rootPath string, paths ...string) ([]domain.Violation, error)
 	RunCheckAll(context context.Context, rootPath string) ([]domain.Violation, error)
+	RunUpdateTodo(context context.Context, rootPath string) error
+	// HoldChecks waits for the checks in flight, and makes the next ones wait until release is called.
+	HoldChecks() (release func())
+	ClearCache(rootPath string, cacheDirectory string) error
+	// Restart stops the checks in flight.
+	Restart()
+	// Configure applies the settings to the checks started from now on.
+	Configure(settings domain.Settings)
 }
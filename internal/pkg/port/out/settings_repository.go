@@ -0,0 +1,9 @@
+package out
+
+import "github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+
+// SettingsRepository keeps the settings currently applied.
+type SettingsRepository interface {
+	Save(settings domain.Settings) error
+	GetSettings() (domain.Settings, error)
+}
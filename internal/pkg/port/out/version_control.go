@@ -0,0 +1,13 @@
+package out
+
+import (
+	"context"
+
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+)
+
+type VersionControl interface {
+	// ChangedLines returns the lines changed in the working tree since the base revision,
+	// e.g. "origin/main" or "HEAD". Files that are not tracked yet count as changed.
+	ChangedLines(context context.Context, rootPath string, base string) (*domain.ChangedLines, error)
+}
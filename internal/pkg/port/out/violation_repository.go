@@ -0,0 +1,14 @@
+package out
+
+import "github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+
+// ViolationRepository keeps the violations found by the latest checks.
+type ViolationRepository interface {
+	// ReplaceAll stores the result of a full check.
+	ReplaceAll(violations []domain.Violation) error
+	// ReplaceFiles stores the result of a check limited to the given files.
+	ReplaceFiles(files []string, violations []domain.Violation) error
+	GetViolations() ([]domain.Violation, error)
+	// GetFixedFiles returns the files that had violations before the latest full check and have none since.
+	GetFixedFiles() ([]string, error)
+}
@@ -0,0 +1,7 @@
+package out
+
+import "github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+
+type YamlParser interface {
+	Parse(text string) (*domain.YamlNode, error)
+}
@@ -0,0 +1,272 @@
+package usecase
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/port/in"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/port/out"
+)
+
+var (
+	keyValueRegex = regexp.MustCompile(`^([A-Za-z_]+):\s*(.*)$`)
+	keyRegex      = regexp.MustCompile(`^([A-Za-z_]*)$`)
+	listItemRegex = regexp.MustCompile(`^(\s*)-\s*(.*)$`)
+)
+
+type packageKey struct {
+	name   string
+	detail string
+	isList bool
+}
+
+var packageKeys = []packageKey{
+	{domain.KeyEnforceDependencies, "Check that referenced packs are declared as dependencies", false},
+	{domain.KeyEnforcePrivacy, "Check that only the public API of this pack is referenced", false},
+	{domain.KeyEnforceVisibility, "Check that only the packs in visible_to reference this pack", false},
+	{domain.KeyEnforceLayers, "Check that dependencies follow the layer order", false},
+	{domain.KeyDependencies, "Packs this pack is allowed to reference", true},
+	{domain.KeyVisibleTo, "Packs allowed to reference this pack", true},
+	{domain.KeyPublicPath, "Folder holding the public API, app/public by default", false},
+	{domain.KeyLayer, "Layer of this pack, one of the layers in packwerk.yml", false},
+	{domain.KeyOwner, "Team owning this pack", false},
+	{domain.KeyMetadata, "Free form metadata", false},
+}
+
+var enforcementKeys = []string{
+	domain.KeyEnforceDependencies,
+	domain.KeyEnforcePrivacy,
+	domain.KeyEnforceVisibility,
+	domain.KeyEnforceLayers,
+}
+
+var enforcementValues = []domain.Enforcement{
+	domain.EnforcementEnabled,
+	domain.EnforcementDisabled,
+	domain.EnforcementStrict,
+}
+
+type Complete struct {
+	workspaceRepository out.WorkspaceRepository
+	packageRepository   out.PackageRepository
+	documentRepository  out.DocumentRepository
+}
+
+func NewComplete(
+	workspaceRepository out.WorkspaceRepository,
+	packageRepository out.PackageRepository,
+	documentRepository out.DocumentRepository,
+) *Complete {
+	return &Complete{
+		workspaceRepository: workspaceRepository,
+		packageRepository:   packageRepository,
+		documentRepository:  documentRepository,
+	}
+}
+
+// Complete suggests keys, enforcement values, layers and pack names in package.yml.
+// The document is analysed line by line because it is usually not valid YAML while typing.
+func (c *Complete) Complete(uri string, position domain.Position) ([]domain.CompletionItem, error) {
+	document, err := c.documentRepository.GetDocument(uri)
+	if err != nil {
+		return nil, err
+	}
+	if !document.IsPackageConfig() {
+		return []domain.CompletionItem{}, nil
+	}
+
+	workspace, err := c.workspaceRepository.GetWorkspace()
+	if err != nil {
+		return nil, err
+	}
+	packageSet, err := c.packageRepository.GetPackageSet()
+	if err != nil {
+		return nil, err
+	}
+
+	lines := document.Lines()
+	if int(position.Line) >= len(lines) {
+		return []domain.CompletionItem{}, nil
+	}
+	line := lines[position.Line]
+	prefix := line[:byteOffset(line, position.Character)]
+	current := packageSet.PackageOf(workspace.StripRootUri(uri))
+
+	if m := listItemRegex.FindStringSubmatch(prefix); m != nil {
+		key := parentKey(lines, int(position.Line), len(m[1]))
+		if !slices.Contains(domain.PackageReferenceKeys, key) {
+			return []domain.CompletionItem{}, nil
+		}
+		listed := listedValues(lines, int(position.Line), key)
+		return packageItems(packageSet, current, listed, position, m[2]), nil
+	}
+
+	if m := keyValueRegex.FindStringSubmatch(prefix); m != nil {
+		key, typed := m[1], m[2]
+		switch {
+		case slices.Contains(enforcementKeys, key):
+			return enforcementItems(position, typed), nil
+		case key == domain.KeyLayer:
+			return layerItems(packageSet.Config, position, typed), nil
+		case slices.Contains(domain.PackageReferenceKeys, key):
+			return packageItems(packageSet, current, nil, position, typed), nil
+		}
+		return []domain.CompletionItem{}, nil
+	}
+
+	if m := keyRegex.FindStringSubmatch(prefix); m != nil {
+		return keyItems(lines, position, m[1]), nil
+	}
+
+	return []domain.CompletionItem{}, nil
+}
+
+func keyItems(lines []string, position domain.Position, typed string) []domain.CompletionItem {
+	items := []domain.CompletionItem{}
+	for _, key := range packageKeys {
+		if hasKey(lines, key.name) {
+			continue
+		}
+		newText := key.name + ": "
+		if key.isList {
+			newText = key.name + ":\n  - "
+		}
+		items = append(items, domain.CompletionItem{
+			Label:  key.name,
+			Kind:   domain.CompletionKindKey,
+			Detail: key.detail,
+			Edit:   domain.TextEdit{Range: typedRange(position, typed), NewText: newText},
+		})
+	}
+	return items
+}
+
+func enforcementItems(position domain.Position, typed string) []domain.CompletionItem {
+	items := make([]domain.CompletionItem, 0, len(enforcementValues))
+	for _, value := range enforcementValues {
+		items = append(items, domain.CompletionItem{
+			Label: string(value),
+			Kind:  domain.CompletionKindValue,
+			Edit:  domain.TextEdit{Range: typedRange(position, typed), NewText: string(value)},
+		})
+	}
+	return items
+}
+
+func layerItems(config *domain.PackwerkConfig, position domain.Position, typed string) []domain.CompletionItem {
+	items := make([]domain.CompletionItem, 0, len(config.Layers))
+	for _, layer := range config.Layers {
+		items = append(items, domain.CompletionItem{
+			Label:  layer,
+			Kind:   domain.CompletionKindValue,
+			Detail: "Layer from packwerk.yml",
+			Edit:   domain.TextEdit{Range: typedRange(position, typed), NewText: layer},
+		})
+	}
+	return items
+}
+
+func packageItems(packageSet *domain.PackageSet, current *domain.Package, listed []string, position domain.Position, typed string) []domain.CompletionItem {
+	items := []domain.CompletionItem{}
+	for _, pkg := range packageSet.All() {
+		if pkg.Name == current.Name || slices.Contains(listed, pkg.Name) {
+			continue
+		}
+		items = append(items, domain.CompletionItem{
+			Label:  pkg.Name,
+			Kind:   domain.CompletionKindPackage,
+			Detail: pkg.ConfigPath(),
+			Edit:   domain.TextEdit{Range: typedRange(position, typed), NewText: pkg.Name},
+		})
+	}
+	return items
+}
+
+// typedRange covers the text typed before the cursor, so pack names containing
+// slashes are replaced as a whole.
+func typedRange(position domain.Position, typed string) domain.Range {
+	start := position
+	start.Character -= min(utf16Len(typed), position.Character)
+	return domain.Range{Start: start, End: position}
+}
+
+// byteOffset converts the character of a position, counted in UTF-16 code units as LSP does,
+// into an index of the line. Characters past the end of the line point at its end,
+// and a character inside a surrogate pair points at the start of the pair.
+func byteOffset(line string, character uint32) int {
+	offset := 0
+	for offset < len(line) {
+		r, size := utf8.DecodeRuneInString(line[offset:])
+		units := uint32(utf16.RuneLen(r))
+		if units > character {
+			break
+		}
+		character -= units
+		offset += size
+	}
+	return offset
+}
+
+// utf16Len returns the length of the text in UTF-16 code units.
+func utf16Len(text string) uint32 {
+	length := uint32(0)
+	for _, r := range text {
+		length += uint32(utf16.RuneLen(r))
+	}
+	return length
+}
+
+// parentKey returns the key owning the list item on the given line.
+func parentKey(lines []string, lineNumber int, indent int) string {
+	for i := lineNumber - 1; i >= 0; i-- {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, "-") {
+			continue
+		}
+		if indentation(line) > indent {
+			continue
+		}
+		if m := keyValueRegex.FindStringSubmatch(trimmed); m != nil && m[2] == "" {
+			return m[1]
+		}
+		return ""
+	}
+	return ""
+}
+
+// listedValues returns the items already listed under the key.
+func listedValues(lines []string, lineNumber int, key string) []string {
+	var values []string
+	inList := false
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if m := keyValueRegex.FindStringSubmatch(line); m != nil {
+			inList = m[1] == key
+			continue
+		}
+		if inList && i != lineNumber && strings.HasPrefix(trimmed, "-") {
+			values = append(values, strings.Trim(strings.TrimSpace(strings.TrimPrefix(trimmed, "-")), `"'`))
+		}
+	}
+	return values
+}
+
+func hasKey(lines []string, key string) bool {
+	for _, line := range lines {
+		if m := keyValueRegex.FindStringSubmatch(line); m != nil && m[1] == key {
+			return true
+		}
+	}
+	return false
+}
+
+func indentation(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+var _ in.Complete = (*Complete)(nil)
@@ -0,0 +1,209 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/adapter/inmemory"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+)
+
+func labels(items []domain.CompletionItem) []string {
+	result := make([]string, 0, len(items))
+	for _, item := range items {
+		result = append(result, item.Label)
+	}
+	return result
+}
+
+func TestComplete_Complete(t *testing.T) {
+	workspaceRepository, packageRepository := setupTestProject(t)
+	workspace, _ := workspaceRepository.GetWorkspace()
+	documentRepository := inmemory.NewDocumentRepository()
+	uc := NewComplete(workspaceRepository, packageRepository, documentRepository)
+
+	uri := workspace.BuildFileUri("packs/users/package.yml")
+
+	tests := []struct {
+		name     string
+		text     string
+		position domain.Position
+		want     []string
+	}{
+		{
+			name:     "pack names in dependencies",
+			text:     "dependencies:\n  - pa\n",
+			position: domain.Position{Line: 1, Character: 6},
+			want:     []string{".", "packs/books", "packs/orders"},
+		},
+		{
+			name:     "already listed packs are skipped",
+			text:     "dependencies:\n  - packs/books\n  - \n",
+			position: domain.Position{Line: 2, Character: 4},
+			want:     []string{".", "packs/orders"},
+		},
+		{
+			name:     "pack names in visible_to without indentation",
+			text:     "visible_to:\n- \n",
+			position: domain.Position{Line: 1, Character: 2},
+			want:     []string{".", "packs/books", "packs/orders"},
+		},
+		{
+			name:     "list under another key",
+			text:     "metadata:\n  tags:\n    - \n",
+			position: domain.Position{Line: 2, Character: 6},
+			want:     []string{},
+		},
+		{
+			name:     "enforcement values",
+			text:     "enforce_privacy: \n",
+			position: domain.Position{Line: 0, Character: 17},
+			want:     []string{"true", "false", "strict"},
+		},
+		{
+			name:     "layers from packwerk.yml",
+			text:     "layer: \n",
+			position: domain.Position{Line: 0, Character: 7},
+			want:     []string{"product", "utility"},
+		},
+		{
+			name:     "keys not yet present",
+			text:     "enforce_dependencies: true\ndependencies:\n  - packs/books\nen\n",
+			position: domain.Position{Line: 3, Character: 2},
+			want:     []string{"enforce_privacy", "enforce_visibility", "enforce_layers", "visible_to", "public_path", "layer", "owner", "metadata"},
+		},
+		{
+			name:     "nested key",
+			text:     "metadata:\n  te\n",
+			position: domain.Position{Line: 1, Character: 4},
+			want:     []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_ = documentRepository.Save(domain.NewDocument(uri, tt.text))
+			got, err := uc.Complete(uri, tt.position)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			gotLabels := labels(got)
+			if len(gotLabels) != len(tt.want) {
+				t.Fatalf("want %v, got %v", tt.want, gotLabels)
+			}
+			for i := range tt.want {
+				if gotLabels[i] != tt.want[i] {
+					t.Errorf("item %d: want %q, got %q", i, tt.want[i], gotLabels[i])
+				}
+			}
+		})
+	}
+}
+
+func TestComplete_Complete_ReplacesTypedText(t *testing.T) {
+	workspaceRepository, packageRepository := setupTestProject(t)
+	workspace, _ := workspaceRepository.GetWorkspace()
+	documentRepository := inmemory.NewDocumentRepository()
+	uc := NewComplete(workspaceRepository, packageRepository, documentRepository)
+
+	uri := workspace.BuildFileUri("packs/users/package.yml")
+	_ = documentRepository.Save(domain.NewDocument(uri, "dependencies:\n  - packs/bo\n"))
+
+	got, err := uc.Complete(uri, domain.Position{Line: 1, Character: 12})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, item := range got {
+		if item.Label != "packs/books" {
+			continue
+		}
+		want := domain.Range{Start: domain.Position{Line: 1, Character: 4}, End: domain.Position{Line: 1, Character: 12}}
+		if item.Edit.Range != want {
+			t.Errorf("want range %+v, got %+v", want, item.Edit.Range)
+		}
+		return
+	}
+	t.Error("expected packs/books to be suggested")
+}
+
+func TestComplete_Complete_NonASCII(t *testing.T) {
+	workspaceRepository, packageRepository := setupTestProject(t)
+	workspace, _ := workspaceRepository.GetWorkspace()
+	documentRepository := inmemory.NewDocumentRepository()
+	uc := NewComplete(workspaceRepository, packageRepository, documentRepository)
+
+	// The cursor is after "packs/本", counted in UTF-16 code units rather than bytes
+	uri := workspace.BuildFileUri("packs/users/package.yml")
+	_ = documentRepository.Save(domain.NewDocument(uri, "dependencies:\n  - packs/本棚\n"))
+
+	got, err := uc.Complete(uri, domain.Position{Line: 1, Character: 11})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) == 0 {
+		t.Fatal("expected packs to be suggested")
+	}
+	want := domain.Range{Start: domain.Position{Line: 1, Character: 4}, End: domain.Position{Line: 1, Character: 11}}
+	if got[0].Edit.Range != want {
+		t.Errorf("want range %+v, got %+v", want, got[0].Edit.Range)
+	}
+}
+
+func TestComplete_Complete_SurrogatePair(t *testing.T) {
+	workspaceRepository, packageRepository := setupTestProject(t)
+	workspace, _ := workspaceRepository.GetWorkspace()
+	documentRepository := inmemory.NewDocumentRepository()
+	uc := NewComplete(workspaceRepository, packageRepository, documentRepository)
+
+	// "🐻" takes two UTF-16 code units, so the cursor after "packs/🐻本" is at 13
+	uri := workspace.BuildFileUri("packs/users/package.yml")
+	_ = documentRepository.Save(domain.NewDocument(uri, "dependencies:\n  - packs/🐻本\n"))
+
+	got, err := uc.Complete(uri, domain.Position{Line: 1, Character: 13})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) == 0 {
+		t.Fatal("expected packs to be suggested")
+	}
+	want := domain.Range{Start: domain.Position{Line: 1, Character: 4}, End: domain.Position{Line: 1, Character: 13}}
+	if got[0].Edit.Range != want {
+		t.Errorf("want range %+v, got %+v", want, got[0].Edit.Range)
+	}
+}
+
+func TestByteOffset(t *testing.T) {
+	line := "a🐻b"
+	tests := []struct {
+		character uint32
+		want      int
+	}{
+		{0, 0},
+		{1, 1},
+		{2, 1}, // inside the surrogate pair
+		{3, 5},
+		{4, 6},
+		{10, 6},
+	}
+	for _, tt := range tests {
+		if got := byteOffset(line, tt.character); got != tt.want {
+			t.Errorf("byteOffset(%q, %d) = %d, want %d", line, tt.character, got, tt.want)
+		}
+	}
+}
+
+func TestComplete_Complete_RubyFile(t *testing.T) {
+	workspaceRepository, packageRepository := setupTestProject(t)
+	workspace, _ := workspaceRepository.GetWorkspace()
+	documentRepository := inmemory.NewDocumentRepository()
+	uc := NewComplete(workspaceRepository, packageRepository, documentRepository)
+
+	uri := workspace.BuildFileUri("packs/users/app/models/user.rb")
+	_ = documentRepository.Save(domain.NewDocument(uri, "class User\nend\n"))
+
+	got, err := uc.Complete(uri, domain.Position{Line: 0, Character: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("expected no items for ruby files, got %v", labels(got))
+	}
+}
@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/port/in"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/port/out"
+)
+
+type ConfigureSettings struct {
+	workspaceRepository out.WorkspaceRepository
+	settingsRepository  out.SettingsRepository
+	packwerkRunner      out.PackwerkRunner
+	fileSystem          out.FileSystem
+	yamlParser          out.YamlParser
+}
+
+func NewConfigureSettings(
+	workspaceRepository out.WorkspaceRepository,
+	settingsRepository out.SettingsRepository,
+	packwerkRunner out.PackwerkRunner,
+	fileSystem out.FileSystem,
+	yamlParser out.YamlParser,
+) *ConfigureSettings {
+	return &ConfigureSettings{
+		workspaceRepository: workspaceRepository,
+		settingsRepository:  settingsRepository,
+		packwerkRunner:      packwerkRunner,
+		fileSystem:          fileSystem,
+		yamlParser:          yamlParser,
+	}
+}
+
+// ProjectSettings reads .wpks-ls.yml at the root of the workspace over the defaults.
+// Without the file, the defaults are returned.
+func (c *ConfigureSettings) ProjectSettings() (domain.Settings, []domain.SettingsError, error) {
+	workspace, err := c.workspaceRepository.GetWorkspace()
+	if err != nil {
+		return domain.NewSettings(), nil, err
+	}
+
+	text, exists, err := c.fileSystem.ReadFile(filepath.Join(workspace.RootPath, domain.ProjectSettingsFile))
+	if err != nil || !exists {
+		return domain.NewSettings(), nil, err
+	}
+
+	root, err := c.yamlParser.Parse(text)
+	if err != nil {
+		var syntaxError *domain.YamlSyntaxError
+		if !errors.As(err, &syntaxError) {
+			return domain.NewSettings(), nil, err
+		}
+		diagnostic := syntaxDiagnostic(syntaxError)
+		return domain.NewSettings(), []domain.SettingsError{{Range: diagnostic.Range, Message: diagnostic.Message}}, nil
+	}
+
+	settings, settingsErrors := domain.ApplyProjectSettings(root, domain.NewSettings())
+	return settings, settingsErrors, nil
+}
+
+// Configure keeps the previous settings when the new ones are invalid.
+func (c *ConfigureSettings) Configure(settings domain.Settings) error {
+	if !domain.IsValidSeverity(settings.Severity) {
+		return fmt.Errorf("invalid severity: %d", settings.Severity)
+	}
+	for kind, severity := range settings.SeverityByKind {
+		if !domain.IsValidSeverity(severity) {
+			return fmt.Errorf("invalid severity of %s violations: %d", kind, severity)
+		}
+	}
+	for _, override := range settings.Overrides {
+		if override.Severity != 0 && !domain.IsValidSeverity(override.Severity) {
+			return fmt.Errorf("invalid severity for %v: %d", override.Paths, override.Severity)
+		}
+		for kind, severity := range override.SeverityByKind {
+			if !domain.IsValidSeverity(severity) {
+				return fmt.Errorf("invalid severity of %s violations for %v: %d", kind, override.Paths, severity)
+			}
+		}
+	}
+	if !domain.IsValidSeverity(settings.BaselineSeverity) {
+		return fmt.Errorf("invalid severity of known violations: %d", settings.BaselineSeverity)
+	}
+	if settings.Concurrency < 1 {
+		return fmt.Errorf("invalid concurrency: %d", settings.Concurrency)
+	}
+	if settings.Timeout < 0 {
+		return fmt.Errorf("invalid timeout: %s", settings.Timeout)
+	}
+	if settings.BatchSize < 1 || settings.BatchDelay < 0 {
+		return fmt.Errorf("invalid batch: %d files every %s", settings.BatchSize, settings.BatchDelay)
+	}
+	if _, err := settings.CompileRules(); err != nil {
+		return err
+	}
+
+	if err := c.settingsRepository.Save(settings); err != nil {
+		return err
+	}
+	c.packwerkRunner.Configure(settings)
+	return nil
+}
+
+var _ in.ConfigureSettings = (*ConfigureSettings)(nil)
@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/adapter/inmemory"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/adapter/packwerk/config"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+)
+
+func TestConfigureSettings_Configure(t *testing.T) {
+	valid := domain.NewSettings()
+	valid.CheckerCommand = []string{"bin/packwerk"}
+	valid.Severity = domain.SeverityHint
+	valid.Timeout = 30 * time.Second
+	valid.Concurrency = 4
+
+	tests := []struct {
+		name    string
+		modify  func(s *domain.Settings)
+		wantErr bool
+	}{
+		{name: "valid settings", modify: func(s *domain.Settings) {}},
+		{name: "unknown severity", modify: func(s *domain.Settings) { s.Severity = 0 }, wantErr: true},
+		{name: "no concurrency", modify: func(s *domain.Settings) { s.Concurrency = 0 }, wantErr: true},
+		{name: "unknown severity of a kind", modify: func(s *domain.Settings) { *s = s.WithSeverity("privacy", 7) }, wantErr: true},
+		{name: "ignored kind", modify: func(s *domain.Settings) { *s = s.WithSeverity("layer", domain.SeverityIgnore) }},
+		{name: "unknown severity of an override", modify: func(s *domain.Settings) {
+			s.Overrides = []domain.SeverityOverride{{Paths: []string{"spec/**"}, Severity: 9}}
+		}, wantErr: true},
+		{name: "invalid override path", modify: func(s *domain.Settings) {
+			s.Overrides = []domain.SeverityOverride{{Paths: []string{"spec/{models"}, Severity: domain.SeverityHint}}
+		}, wantErr: true},
+		{name: "empty batch", modify: func(s *domain.Settings) { s.BatchSize = 0 }, wantErr: true},
+		{name: "negative timeout", modify: func(s *domain.Settings) { s.Timeout = -time.Second }, wantErr: true},
+		{name: "invalid exclude path", modify: func(s *domain.Settings) { s.ExcludePaths = []string{"spec/{models"} }, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settingsRepository := inmemory.NewSettingsRepository()
+			runner := &fakePackwerkRunner{}
+			uc := NewConfigureSettings(setupTestRepository(t), settingsRepository, runner, &fakeFileSystem{files: map[string]string{}}, config.NewYamlParser())
+
+			settings := valid
+			tt.modify(&settings)
+			err := uc.Configure(settings)
+
+			saved, _ := settingsRepository.GetSettings()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				if saved.Severity != domain.SeverityError || runner.configured != nil {
+					t.Errorf("want the previous settings to be kept, got %+v", saved)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if saved.Severity != domain.SeverityHint || runner.configured == nil || runner.configured.Concurrency != 4 {
+				t.Errorf("want the settings to be saved and applied to the runner, got %+v", saved)
+			}
+		})
+	}
+}
+
+func TestConfigureSettings_ProjectSettings(t *testing.T) {
+	settingsPath := filepath.Join(testRootPath, domain.ProjectSettingsFile)
+
+	tests := []struct {
+		name         string
+		files        map[string]string
+		wantSeverity int32
+		wantErrLines []uint32
+	}{
+		{
+			name:         "missing file yields the defaults",
+			files:        map[string]string{},
+			wantSeverity: domain.SeverityError,
+		},
+		{
+			name:         "valid file",
+			files:        map[string]string{settingsPath: "severity: warning\nchecker:\n  command: bin/packwerk\n"},
+			wantSeverity: domain.SeverityWarning,
+		},
+		{
+			name:         "invalid entries are skipped",
+			files:        map[string]string{settingsPath: "severity: hint\nchecker:\n  timeout: later\nexclude: []\n"},
+			wantSeverity: domain.SeverityHint,
+			wantErrLines: []uint32{2, 3},
+		},
+		{
+			name:         "syntax error",
+			files:        map[string]string{settingsPath: "severity: [\n"},
+			wantSeverity: domain.SeverityError,
+			wantErrLines: []uint32{0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := NewConfigureSettings(
+				setupTestRepository(t),
+				inmemory.NewSettingsRepository(),
+				&fakePackwerkRunner{},
+				&fakeFileSystem{files: tt.files},
+				config.NewYamlParser(),
+			)
+
+			settings, settingsErrors, err := uc.ProjectSettings()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if settings.Severity != tt.wantSeverity {
+				t.Errorf("want severity %d, got %d", tt.wantSeverity, settings.Severity)
+			}
+			if len(settingsErrors) != len(tt.wantErrLines) {
+				t.Fatalf("want %d errors, got %+v", len(tt.wantErrLines), settingsErrors)
+			}
+			for i, line := range tt.wantErrLines {
+				if settingsErrors[i].Range.Start.Line != line {
+					t.Errorf("error %d: want line %d, got %d", i, line, settingsErrors[i].Range.Start.Line)
+				}
+			}
+		})
+	}
+}
@@ -2,6 +2,8 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	"github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
 	"github.com/rinsyan0518/wpks-ls/internal/pkg/port/in"
@@ -14,11 +16,35 @@ const (
 
 type DiagnoseFile struct {
 	workspaceRepository out.WorkspaceRepository
+	packageRepository   out.PackageRepository
+	violationRepository out.ViolationRepository
+	settingsRepository  out.SettingsRepository
+	configReader        out.PackwerkConfigReader
+	fileSystem          out.FileSystem
+	versionControl      out.VersionControl
 	packwerkRunner      out.PackwerkRunner
 }
 
-func NewDiagnoseFile(workspaceRepository out.WorkspaceRepository, packwerkRunner out.PackwerkRunner) *DiagnoseFile {
-	return &DiagnoseFile{workspaceRepository: workspaceRepository, packwerkRunner: packwerkRunner}
+func NewDiagnoseFile(
+	workspaceRepository out.WorkspaceRepository,
+	packageRepository out.PackageRepository,
+	violationRepository out.ViolationRepository,
+	settingsRepository out.SettingsRepository,
+	configReader out.PackwerkConfigReader,
+	fileSystem out.FileSystem,
+	versionControl out.VersionControl,
+	packwerkRunner out.PackwerkRunner,
+) *DiagnoseFile {
+	return &DiagnoseFile{
+		workspaceRepository: workspaceRepository,
+		packageRepository:   packageRepository,
+		violationRepository: violationRepository,
+		settingsRepository:  settingsRepository,
+		configReader:        configReader,
+		fileSystem:          fileSystem,
+		versionControl:      versionControl,
+		packwerkRunner:      packwerkRunner,
+	}
 }
 
 func (d *DiagnoseFile) Diagnose(context context.Context, uris ...string) (map[string][]domain.Diagnostic, error) {
@@ -42,24 +68,11 @@ func (d *DiagnoseFile) Diagnose(context context.Context, uris ...string) (map[st
 	if err != nil {
 		return nil, err
 	}
-
-	// Group violations by file URI
-	allDiagnostics := make(map[string][]domain.Diagnostic)
-	for _, v := range violations {
-		fileUri := workspace.BuildFileUri(v.File)
-		diagnostic := domain.Diagnostic{
-			Range: domain.Range{
-				Start: domain.Position{Line: v.Line - 1, Character: v.Character},
-				End:   domain.Position{Line: v.Line - 1, Character: v.Character + 1},
-			},
-			Severity: domain.SeverityError,
-			Source:   packwerkSource,
-			Message:  v.Message,
-		}
-		allDiagnostics[fileUri] = append(allDiagnostics[fileUri], diagnostic)
+	if err := d.violationRepository.ReplaceFiles(paths, violations); err != nil {
+		return nil, err
 	}
 
-	return allDiagnostics, nil
+	return d.buildDiagnostics(context, workspace, paths, violations)
 }
 
 func (d *DiagnoseFile) DiagnoseAll(context context.Context) (map[string][]domain.Diagnostic, error) {
@@ -75,25 +88,172 @@ func (d *DiagnoseFile) DiagnoseAll(context context.Context) (map[string][]domain
 	if err != nil {
 		return nil, err
 	}
+	// The files fixed since the previous check get an entry too
+	previous, err := d.violationRepository.GetViolations()
+	if err != nil {
+		return nil, err
+	}
+	if err := d.violationRepository.ReplaceAll(violations); err != nil {
+		return nil, err
+	}
 
-	diagnosticsByFile := make(map[string][]domain.Diagnostic)
+	return d.buildDiagnostics(context, workspace, violationFiles(previous), violations)
+}
 
-	for _, v := range violations {
+// DiagnoseChanged checks the files changed since the diff base of the settings, among those packwerk checks.
+func (d *DiagnoseFile) DiagnoseChanged(context context.Context) (map[string][]domain.Diagnostic, error) {
+	workspace, err := d.workspaceRepository.GetWorkspace()
+	if err != nil {
+		return nil, err
+	}
+	settings, err := d.settingsRepository.GetSettings()
+	if err != nil {
+		return nil, err
+	}
+	if settings.DiffBase == "" {
+		return nil, errors.New("no diff base is set")
+	}
+	changes, err := d.versionControl.ChangedLines(context, workspace.RootPath, settings.DiffBase)
+	if err != nil {
+		return nil, err
+	}
+
+	config := domain.NewPackwerkConfig()
+	if packageSet, err := d.packageRepository.GetPackageSet(); err == nil && packageSet.Config != nil {
+		config = packageSet.Config
+	}
+	uris := []string{}
+	for _, filePath := range changes.Files() {
+		if config.IsCheckedFile(filePath) {
+			uris = append(uris, workspace.BuildFileUri(filePath))
+		}
+	}
+	return d.Diagnose(context, uris...)
+}
+
+// Rebuild converts the violations of the latest checks again, after the settings or the baseline changed.
+// Every file with violations gets an entry, so the diagnostics of excluded files are cleared,
+// and so do the files fixed by the latest full check, which may not have been published since.
+func (d *DiagnoseFile) Rebuild() (map[string][]domain.Diagnostic, error) {
+	workspace, err := d.workspaceRepository.GetWorkspace()
+	if err != nil {
+		return nil, err
+	}
+
+	violations, err := d.violationRepository.GetViolations()
+	if err != nil {
+		return nil, err
+	}
+	fixed, err := d.violationRepository.GetFixedFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	return d.buildDiagnostics(context.Background(), workspace, append(violationFiles(violations), fixed...), violations)
+}
+
+// buildDiagnostics groups the violations by file URI, as reportedViolations keeps them.
+// Each diagnostic links the definition of the constant and the package.yml lines involved.
+// Every file given gets an entry, empty when none of its violations is kept, so that its previous diagnostics are cleared.
+func (d *DiagnoseFile) buildDiagnostics(context context.Context, workspace *domain.Workspace, files []string, violations []domain.Violation) (map[string][]domain.Diagnostic, error) {
+	reported, rules, err := reportedViolations(context, d.settingsRepository, d.fileSystem, d.versionControl, workspace, violations)
+	if err != nil {
+		return nil, err
+	}
+
+	relations := newViolationRelations(workspace, d.packageRepository, d.configReader, d.fileSystem)
+	diagnosticsByFile := make(map[string][]domain.Diagnostic, len(files))
+	for _, file := range files {
+		diagnosticsByFile[workspace.BuildFileUri(file)] = []domain.Diagnostic{}
+	}
+	for _, r := range reported {
+		v := r.Violation
 		fileUri := workspace.BuildFileUri(v.File)
 		diagnostic := domain.Diagnostic{
-			Range: domain.Range{
-				Start: domain.Position{Line: v.Line - 1, Character: v.Character},
-				End:   domain.Position{Line: v.Line - 1, Character: v.Character + 1},
-			},
-			Severity: domain.SeverityError,
-			Source:   packwerkSource,
-			Message:  v.Message,
+			Range:              v.Range(),
+			Severity:           r.severity,
+			Source:             packwerkSource,
+			Code:               v.Kind(),
+			CodeHref:           v.HelpURL,
+			Message:            rules.Message(v),
+			RelatedInformation: relations.Of(v),
+			Violation:          &v,
+			Known:              r.known,
 		}
-
 		diagnosticsByFile[fileUri] = append(diagnosticsByFile[fileUri], diagnostic)
 	}
-
 	return diagnosticsByFile, nil
 }
 
+// reportedViolation is a violation kept by the settings, with the severity it is reported at.
+type reportedViolation struct {
+	domain.Violation
+	severity int32
+	known    bool // listed in the baseline
+}
+
+// reportedViolations applies the severity rules of the settings to the violations, along with the compiled rules.
+// Violations listed in the baseline are lowered to its severity, and with a diff base,
+// only the violations on changed lines are kept.
+func reportedViolations(
+	context context.Context,
+	settingsRepository out.SettingsRepository,
+	fileSystem out.FileSystem,
+	versionControl out.VersionControl,
+	workspace *domain.Workspace,
+	violations []domain.Violation,
+) ([]reportedViolation, *domain.ViolationRules, error) {
+	settings, err := settingsRepository.GetSettings()
+	if err != nil {
+		return nil, nil, err
+	}
+	rules, err := settings.CompileRules()
+	if err != nil {
+		return nil, nil, err
+	}
+	var changes *domain.ChangedLines
+	if settings.DiffBase != "" {
+		changes, err = versionControl.ChangedLines(context, workspace.RootPath, settings.DiffBase)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read the changes since %s: %w", settings.DiffBase, err)
+		}
+	}
+	baseline, _, err := readBaseline(fileSystem, workspace)
+	if err != nil {
+		// ValidateBaseline tells the user, and the violations are reported as new until the file is fixed
+		baseline = &domain.Baseline{}
+	}
+	known := baseline.Known(violations)
+
+	reported := make([]reportedViolation, 0, len(violations))
+	for i, v := range violations {
+		if changes != nil && !changes.Contains(v.File, v.Line) {
+			continue
+		}
+		severity, ok := rules.Severity(v)
+		if ok && known[i] {
+			severity, ok = rules.KnownSeverity(severity)
+		}
+		if !ok {
+			continue
+		}
+		reported = append(reported, reportedViolation{Violation: v, severity: severity, known: known[i]})
+	}
+	return reported, rules, nil
+}
+
+// violationFiles returns the files with violations, each once.
+func violationFiles(violations []domain.Violation) []string {
+	files := make([]string, 0, len(violations))
+	seen := make(map[string]struct{}, len(violations))
+	for _, v := range violations {
+		if _, ok := seen[v.File]; ok {
+			continue
+		}
+		seen[v.File] = struct{}{}
+		files = append(files, v.File)
+	}
+	return files
+}
+
 var _ in.DiagnoseFile = (*DiagnoseFile)(nil)
@@ -2,13 +2,16 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/adapter/filesystem"
 	"github.com/rinsyan0518/wpks-ls/internal/pkg/adapter/inmemory"
 	"github.com/rinsyan0518/wpks-ls/internal/pkg/adapter/packwerk"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/adapter/packwerk/config"
 	"github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
 )
 
@@ -29,6 +32,14 @@ const (
 // fakePackwerkRunner is a mock implementation for testing
 type fakePackwerkRunner struct {
 	output string
+
+	updatedTodo  bool
+	onUpdateTodo func() // simulates the files written by update-todo
+	holding      bool   // set between HoldChecks and its release
+	heldTodo     bool   // set when update-todo ran while the checks were held
+	clearedCache string
+	restarted    bool
+	configured   *domain.Settings
 }
 
 func (f *fakePackwerkRunner) RunCheck(ctx context.Context, rootPath string, paths ...string) ([]domain.Violation, error) {
@@ -57,6 +68,47 @@ func (f *fakePackwerkRunner) RunCheckAll(ctx context.Context, rootPath string) (
 	return packwerk.NewPackwerkOutput(f.output).Parse(), nil
 }
 
+func (f *fakePackwerkRunner) RunUpdateTodo(ctx context.Context, rootPath string) error {
+	f.updatedTodo = true
+	f.heldTodo = f.holding
+	if f.onUpdateTodo != nil {
+		f.onUpdateTodo()
+	}
+	return nil
+}
+
+func (f *fakePackwerkRunner) HoldChecks() func() {
+	f.holding = true
+	return func() { f.holding = false }
+}
+
+func (f *fakePackwerkRunner) ClearCache(rootPath string, cacheDirectory string) error {
+	f.clearedCache = filepath.Join(rootPath, cacheDirectory)
+	return nil
+}
+
+func (f *fakePackwerkRunner) Restart() {
+	f.restarted = true
+}
+
+func (f *fakePackwerkRunner) Configure(settings domain.Settings) {
+	f.configured = &settings
+}
+
+// fakeVersionControl returns the changes it is given, or an error without changes.
+type fakeVersionControl struct {
+	changes *domain.ChangedLines
+	base    string
+}
+
+func (f *fakeVersionControl) ChangedLines(ctx context.Context, rootPath string, base string) (*domain.ChangedLines, error) {
+	f.base = base
+	if f.changes == nil {
+		return nil, errors.New("not a git repository")
+	}
+	return f.changes, nil
+}
+
 // Test helper functions
 
 // setupTestRepository creates and configures a test repository
@@ -86,7 +138,7 @@ func createDiagnoser(t *testing.T, fixtureFile string) *DiagnoseFile {
 	t.Helper()
 	repo := setupTestRepository(t)
 	output := loadTestFixture(t, fixtureFile)
-	return NewDiagnoseFile(repo, &fakePackwerkRunner{output: output})
+	return NewDiagnoseFile(repo, inmemory.NewPackageRepository(), inmemory.NewViolationRepository(), inmemory.NewSettingsRepository(), config.NewReader(), &fakeFileSystem{files: map[string]string{}}, &fakeVersionControl{}, &fakePackwerkRunner{output: output})
 }
 
 // assertTotalDiagnosticCount checks if the total number of diagnostics matches expected count
@@ -226,3 +278,336 @@ func TestDiagnoseFile_DiagnoseAll(t *testing.T) {
 		})
 	}
 }
+
+func TestDiagnoseFile_StoresViolations(t *testing.T) {
+	violationRepository := inmemory.NewViolationRepository()
+	output := loadTestFixture(t, "packwerk_output_multiple.txt")
+	diagnoser := NewDiagnoseFile(setupTestRepository(t), inmemory.NewPackageRepository(), violationRepository, inmemory.NewSettingsRepository(), config.NewReader(), &fakeFileSystem{files: map[string]string{}}, &fakeVersionControl{}, &fakePackwerkRunner{output: output})
+
+	if _, err := diagnoser.DiagnoseAll(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	violations, _ := violationRepository.GetViolations()
+	if len(violations) != 2 {
+		t.Fatalf("want 2 violations after a full check, got %d", len(violations))
+	}
+
+	// Checking a file replaces only the violations of that file
+	if _, err := diagnoser.Diagnose(context.Background(), testURI1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	violations, _ = violationRepository.GetViolations()
+	if len(violations) != 4 {
+		t.Fatalf("want 4 violations, got %d", len(violations))
+	}
+	if _, err := diagnoser.Diagnose(context.Background(), testURI1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	violations, _ = violationRepository.GetViolations()
+	if len(violations) != 4 {
+		t.Errorf("want violations of a re-checked file to be replaced, got %d", len(violations))
+	}
+}
+
+func TestDiagnoseFile_Rebuild(t *testing.T) {
+	settingsRepository := inmemory.NewSettingsRepository()
+	output := loadTestFixture(t, "packwerk_output_multiple.txt")
+	diagnoser := NewDiagnoseFile(setupTestRepository(t), inmemory.NewPackageRepository(), inmemory.NewViolationRepository(), settingsRepository, config.NewReader(), &fakeFileSystem{files: map[string]string{}}, &fakeVersionControl{}, &fakePackwerkRunner{output: output})
+
+	if _, err := diagnoser.Diagnose(context.Background(), testURI1, testURI2); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	settings := domain.NewSettings()
+	settings.Severity = domain.SeverityWarning
+	settings.ExcludePaths = []string{"lib/another.rb"}
+	_ = settingsRepository.Save(settings)
+
+	got, err := diagnoser.Rebuild()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got[testURI1]) != 2 || got[testURI1][0].Severity != domain.SeverityWarning {
+		t.Errorf("want the diagnostics of %s with the new severity, got %+v", testURI1, got[testURI1])
+	}
+	diagnostics, ok := got[testURI2]
+	if !ok || len(diagnostics) != 0 {
+		t.Errorf("want the diagnostics of the excluded %s to be cleared, got %+v", testURI2, diagnostics)
+	}
+}
+
+func TestDiagnoseFile_ClearsFilesWithoutDiagnostics(t *testing.T) {
+	runner := &fakePackwerkRunner{output: loadTestFixture(t, "packwerk_output_multiple.txt")}
+	diagnoser := NewDiagnoseFile(setupTestRepository(t), inmemory.NewPackageRepository(), inmemory.NewViolationRepository(), inmemory.NewSettingsRepository(), config.NewReader(), &fakeFileSystem{files: map[string]string{}}, &fakeVersionControl{}, runner)
+
+	if _, err := diagnoser.DiagnoseAll(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The violations were fixed
+	runner.output = loadTestFixture(t, "packwerk_output_empty.txt")
+	got, err := diagnoser.Diagnose(context.Background(), testURI1, testURI2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, uri := range []string{testURI1, testURI2} {
+		if diagnostics, ok := got[uri]; !ok || len(diagnostics) != 0 {
+			t.Errorf("want the diagnostics of the checked %s to be cleared, got %+v", uri, diagnostics)
+		}
+	}
+
+	got, err = diagnoser.DiagnoseAll(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diagnostics, ok := got[expectedFileURI]; !ok || len(diagnostics) != 0 {
+		t.Errorf("want the diagnostics of the fixed %s to be cleared, got %+v", expectedFileURI, diagnostics)
+	}
+}
+
+func TestDiagnoseFile_SeverityRules(t *testing.T) {
+	settingsRepository := inmemory.NewSettingsRepository()
+	settings := domain.NewSettings().WithSeverity("dependency", domain.SeverityWarning)
+	settings.Overrides = []domain.SeverityOverride{
+		{Paths: []string{"lib/another.rb"}, SeverityByKind: map[string]int32{"dependency": domain.SeverityIgnore}},
+	}
+	_ = settingsRepository.Save(settings)
+
+	output := loadTestFixture(t, "packwerk_output_multiple.txt")
+	diagnoser := NewDiagnoseFile(setupTestRepository(t), inmemory.NewPackageRepository(), inmemory.NewViolationRepository(), settingsRepository, config.NewReader(), &fakeFileSystem{files: map[string]string{}}, &fakeVersionControl{}, &fakePackwerkRunner{output: output})
+
+	got, err := diagnoser.Diagnose(context.Background(), testURI1, testURI2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got[testURI1]) != 2 || got[testURI1][0].Severity != domain.SeverityWarning {
+		t.Errorf("want dependency violations reported as warnings, got %+v", got[testURI1])
+	}
+	if diagnostics, ok := got[testURI2]; !ok || len(diagnostics) != 0 {
+		t.Errorf("want the ignored violations of %s to be cleared, got %+v", testURI2, diagnostics)
+	}
+}
+
+func TestDiagnoseFile_DiagnoseAll_Code(t *testing.T) {
+	diagnoser := createDiagnoser(t, "packwerk_output_multiple.txt")
+
+	got, err := diagnoser.DiagnoseAll(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	diagnostics := got[expectedFileURI]
+	if len(diagnostics) == 0 {
+		t.Fatalf("expected diagnostics for %s", expectedFileURI)
+	}
+	d := diagnostics[0]
+	if d.Code != "dependency" {
+		t.Errorf("want code %q, got %q", "dependency", d.Code)
+	}
+	if want := "https://github.com/Shopify/packwerk/blob/main/TROUBLESHOOT.md#Troubleshooting-violations"; d.CodeHref != want {
+		t.Errorf("want code href %q, got %q", want, d.CodeHref)
+	}
+	if d.Violation == nil || d.Violation.Constant != "::Book" || d.Violation.ReferencedPackage != "packs/books" {
+		t.Errorf("want the violation to be attached, got %+v", d.Violation)
+	}
+}
+
+func TestDiagnoseFile_RelatedInformation(t *testing.T) {
+	type related struct {
+		path    string
+		line    uint32
+		message string
+	}
+	tests := []struct {
+		name        string
+		fixtureFile string
+		want        []related
+	}{
+		{
+			name:        "dependency violation",
+			fixtureFile: "packwerk_output_multiple.txt",
+			want: []related{
+				{"packs/books/app/models/book.rb", 0, "::Book is defined here"},
+				{"packs/users/package.yml", 2, "'packs/users' does not list 'packs/books' in its dependencies"},
+				{"packs/users/package.yml", 0, "'packs/users' sets enforce_dependencies: true"},
+			},
+		},
+		{
+			name:        "privacy violation",
+			fixtureFile: "packwerk_output_privacy.txt",
+			want: []related{
+				{"packs/books/app/models/books/inventory.rb", 1, "::Books::Inventory is defined here"},
+				{"packs/books/package.yml", 1, "'packs/books' sets enforce_privacy: true"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workspaceRepository, packageRepository := setupTestProject(t)
+			workspace, _ := workspaceRepository.GetWorkspace()
+			output := loadTestFixture(t, tt.fixtureFile)
+			diagnoser := NewDiagnoseFile(workspaceRepository, packageRepository, inmemory.NewViolationRepository(), inmemory.NewSettingsRepository(), config.NewReader(), filesystem.NewFileSystem(), &fakeVersionControl{}, &fakePackwerkRunner{output: output})
+
+			got, err := diagnoser.DiagnoseAll(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var diagnostics []domain.Diagnostic
+			for _, d := range got {
+				diagnostics = append(diagnostics, d...)
+			}
+			if len(diagnostics) == 0 {
+				t.Fatal("expected diagnostics")
+			}
+			infos := diagnostics[0].RelatedInformation
+			if len(infos) != len(tt.want) {
+				t.Fatalf("want %d related information, got %+v", len(tt.want), infos)
+			}
+			for i, want := range tt.want {
+				info := infos[i]
+				if info.Location.URI != workspace.BuildFileUri(want.path) || info.Location.Range.Start.Line != want.line || info.Message != want.message {
+					t.Errorf("related information %d: got %s:%d %q, want %s:%d %q", i,
+						info.Location.URI, info.Location.Range.Start.Line, info.Message, want.path, want.line, want.message)
+				}
+			}
+		})
+	}
+}
+
+func TestDiagnoseFile_RelatedInformation_PackagesNotLoaded(t *testing.T) {
+	diagnoser := createDiagnoser(t, "packwerk_output_multiple.txt")
+
+	got, err := diagnoser.DiagnoseAll(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	infos := got[expectedFileURI][0].RelatedInformation
+	if len(infos) != 1 || infos[0].Location.URI != "file:///root/packs/books/app/models/book.rb" {
+		t.Errorf("want only the definition to be linked, got %+v", infos)
+	}
+	if infos[0].Location.Range != (domain.Range{}) {
+		t.Errorf("want the top of an unreadable definition file, got %+v", infos[0].Location.Range)
+	}
+}
+
+func TestDiagnoseFile_MessageTemplate(t *testing.T) {
+	settingsRepository := inmemory.NewSettingsRepository()
+	settings := domain.NewSettings()
+	settings.MessageTemplate = domain.MessageTemplateShort
+	_ = settingsRepository.Save(settings)
+
+	output := loadTestFixture(t, "packwerk_output_multiple.txt")
+	diagnoser := NewDiagnoseFile(setupTestRepository(t), inmemory.NewPackageRepository(), inmemory.NewViolationRepository(), settingsRepository, config.NewReader(), &fakeFileSystem{files: map[string]string{}}, &fakeVersionControl{}, &fakePackwerkRunner{output: output})
+
+	got, err := diagnoser.DiagnoseAll(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertDiagnosticsForURI(t, got, expectedFileURI, []string{
+		"::Book → packs/books (no dependency from packs/users)",
+		"::Book → packs/books (no dependency from packs/users)",
+	})
+	if got[expectedFileURI][0].Violation.Message != expectedViolationMessage {
+		t.Errorf("want the full message kept on the violation, got %q", got[expectedFileURI][0].Violation.Message)
+	}
+}
+
+func TestDiagnoseFile_DiffBase(t *testing.T) {
+	settingsRepository := inmemory.NewSettingsRepository()
+	settings := domain.NewSettings()
+	settings.DiffBase = "HEAD"
+	_ = settingsRepository.Save(settings)
+
+	changes := domain.NewChangedLines()
+	changes.Add("packs/users/app/controllers/users_controller.rb", 18, 20)
+	versionControl := &fakeVersionControl{changes: changes}
+	output := loadTestFixture(t, "packwerk_output_multiple.txt")
+	diagnoser := NewDiagnoseFile(setupTestRepository(t), inmemory.NewPackageRepository(), inmemory.NewViolationRepository(), settingsRepository, config.NewReader(), &fakeFileSystem{files: map[string]string{}}, versionControl, &fakePackwerkRunner{output: output})
+
+	got, err := diagnoser.DiagnoseAll(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if versionControl.base != "HEAD" {
+		t.Errorf("want the changes since HEAD, got %q", versionControl.base)
+	}
+	diagnostics := got[expectedFileURI]
+	if len(diagnostics) != 1 || diagnostics[0].Range.Start.Line != 19 {
+		t.Errorf("want only the violation on the changed line 20, got %+v", diagnostics)
+	}
+
+	versionControl.changes = nil
+	if _, err := diagnoser.DiagnoseAll(context.Background()); err == nil || !strings.Contains(err.Error(), "failed to read the changes since HEAD") {
+		t.Errorf("want the error of git, got %v", err)
+	}
+}
+
+func TestDiagnoseFile_DiagnoseChanged(t *testing.T) {
+	settingsRepository := inmemory.NewSettingsRepository()
+	changes := domain.NewChangedLines()
+	changes.Add("lib/sample.rb", 20, 20)
+	changes.AddFile("README.md")
+	output := loadTestFixture(t, "packwerk_output_multiple.txt")
+	diagnoser := NewDiagnoseFile(setupTestRepository(t), inmemory.NewPackageRepository(), inmemory.NewViolationRepository(), settingsRepository, config.NewReader(), &fakeFileSystem{files: map[string]string{}}, &fakeVersionControl{changes: changes}, &fakePackwerkRunner{output: output})
+
+	if _, err := diagnoser.DiagnoseChanged(context.Background()); err == nil {
+		t.Error("want an error without a diff base")
+	}
+
+	settings := domain.NewSettings()
+	settings.DiffBase = "origin/main"
+	_ = settingsRepository.Save(settings)
+	got, err := diagnoser.DiagnoseChanged(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 1 || len(got[testURI1]) != 1 {
+		t.Errorf("want the violation on the changed line of %s only, got %+v", testURI1, got)
+	}
+}
+
+func TestDiagnoseFile_Baseline(t *testing.T) {
+	const file = "packs/users/app/controllers/users_controller.rb"
+	// The baseline was created when ::Book was referenced once
+	baseline, _ := domain.NewBaseline([]domain.Violation{{File: file, Type: "Dependency violation", Constant: "::Book", ReferencedPackage: "packs/books"}}).Text()
+	fileSystem := &fakeFileSystem{files: map[string]string{filepath.Join(testRootPath, domain.BaselineFile): baseline}}
+	settingsRepository := inmemory.NewSettingsRepository()
+	output := loadTestFixture(t, "packwerk_output_multiple.txt")
+	diagnoser := NewDiagnoseFile(setupTestRepository(t), inmemory.NewPackageRepository(), inmemory.NewViolationRepository(), settingsRepository, config.NewReader(), fileSystem, &fakeVersionControl{}, &fakePackwerkRunner{output: output})
+
+	got, err := diagnoser.DiagnoseAll(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	diagnostics := got[expectedFileURI]
+	if len(diagnostics) != 2 {
+		t.Fatalf("want 2 diagnostics, got %+v", diagnostics)
+	}
+	if !diagnostics[0].Known || diagnostics[0].Severity != domain.SeverityHint {
+		t.Errorf("want the first reference known as a hint, got %+v", diagnostics[0])
+	}
+	if diagnostics[1].Known || diagnostics[1].Severity != domain.SeverityError {
+		t.Errorf("want the second reference new as an error, got %+v", diagnostics[1])
+	}
+
+	settings := domain.NewSettings()
+	settings.BaselineSeverity = domain.SeverityIgnore
+	_ = settingsRepository.Save(settings)
+	got, err = diagnoser.Rebuild()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diagnostics := got[expectedFileURI]; len(diagnostics) != 1 || diagnostics[0].Range.Start.Line != 25 {
+		t.Errorf("want the known reference hidden, got %+v", diagnostics)
+	}
+
+	// A broken baseline reports every violation as new rather than failing the diagnostics
+	fileSystem.files[filepath.Join(testRootPath, domain.BaselineFile)] = "{"
+	got, err = diagnoser.Rebuild()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diagnostics := got[expectedFileURI]; len(diagnostics) != 2 || diagnostics[0].Known || diagnostics[1].Known {
+		t.Errorf("want both references new, got %+v", diagnostics)
+	}
+}
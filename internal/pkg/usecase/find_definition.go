@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/port/in"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/port/out"
+)
+
+type FindDefinition struct {
+	workspaceRepository out.WorkspaceRepository
+	packageRepository   out.PackageRepository
+	documentRepository  out.DocumentRepository
+	yamlParser          out.YamlParser
+}
+
+func NewFindDefinition(
+	workspaceRepository out.WorkspaceRepository,
+	packageRepository out.PackageRepository,
+	documentRepository out.DocumentRepository,
+	yamlParser out.YamlParser,
+) *FindDefinition {
+	return &FindDefinition{
+		workspaceRepository: workspaceRepository,
+		packageRepository:   packageRepository,
+		documentRepository:  documentRepository,
+		yamlParser:          yamlParser,
+	}
+}
+
+// Definition jumps from a pack name listed in package.yml to that pack's package.yml.
+func (f *FindDefinition) Definition(uri string, position domain.Position) ([]domain.Location, error) {
+	document, err := f.documentRepository.GetDocument(uri)
+	if err != nil {
+		return nil, err
+	}
+	if !document.IsPackageConfig() {
+		return []domain.Location{}, nil
+	}
+
+	root, err := f.yamlParser.Parse(document.Text)
+	if err != nil {
+		// The document is being edited, there is nothing to jump to
+		return []domain.Location{}, nil
+	}
+
+	workspace, err := f.workspaceRepository.GetWorkspace()
+	if err != nil {
+		return nil, err
+	}
+	packageSet, err := f.packageRepository.GetPackageSet()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, key := range domain.PackageReferenceKeys {
+		item := root.Get(key).ItemAt(position)
+		if item == nil {
+			continue
+		}
+		pkg, ok := packageSet.Get(item.Value)
+		if !ok {
+			return []domain.Location{}, nil
+		}
+		return []domain.Location{{URI: workspace.BuildFileUri(pkg.ConfigPath())}}, nil
+	}
+
+	return []domain.Location{}, nil
+}
+
+var _ in.FindDefinition = (*FindDefinition)(nil)
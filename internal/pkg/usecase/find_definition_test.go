@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/adapter/inmemory"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/adapter/packwerk/config"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+)
+
+const testPackageYml = `enforce_dependencies: true
+dependencies:
+  - packs/books
+  - packs/unknown
+visible_to:
+  - .
+`
+
+func TestFindDefinition_Definition(t *testing.T) {
+	workspaceRepository, packageRepository := setupTestProject(t)
+	workspace, _ := workspaceRepository.GetWorkspace()
+	documentRepository := inmemory.NewDocumentRepository()
+	uc := NewFindDefinition(workspaceRepository, packageRepository, documentRepository, config.NewYamlParser())
+
+	packageYmlURI := workspace.BuildFileUri("packs/users/package.yml")
+	rubyURI := workspace.BuildFileUri("packs/users/app/models/user.rb")
+	_ = documentRepository.Save(domain.NewDocument(packageYmlURI, testPackageYml))
+	_ = documentRepository.Save(domain.NewDocument(rubyURI, "class User\nend\n"))
+
+	tests := []struct {
+		name     string
+		uri      string
+		position domain.Position
+		want     []string
+	}{
+		{"dependency", packageYmlURI, domain.Position{Line: 2, Character: 8}, []string{workspace.BuildFileUri("packs/books/package.yml")}},
+		{"visible_to root pack", packageYmlURI, domain.Position{Line: 5, Character: 4}, []string{workspace.BuildFileUri("package.yml")}},
+		{"unknown pack", packageYmlURI, domain.Position{Line: 3, Character: 8}, []string{}},
+		{"on a key", packageYmlURI, domain.Position{Line: 0, Character: 3}, []string{}},
+		{"ruby file", rubyURI, domain.Position{Line: 0, Character: 3}, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := uc.Definition(tt.uri, tt.position)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("want %d locations, got %d", len(tt.want), len(got))
+			}
+			for i, uri := range tt.want {
+				if got[i].URI != uri {
+					t.Errorf("location %d: want %q, got %q", i, uri, got[i].URI)
+				}
+			}
+		})
+	}
+}
+
+func TestFindDefinition_Definition_DocumentNotOpen(t *testing.T) {
+	workspaceRepository, packageRepository := setupTestProject(t)
+	uc := NewFindDefinition(workspaceRepository, packageRepository, inmemory.NewDocumentRepository(), config.NewYamlParser())
+	if _, err := uc.Definition("file:///root/packs/users/package.yml", domain.Position{}); err == nil {
+		t.Error("expected error for a document that is not open")
+	}
+}
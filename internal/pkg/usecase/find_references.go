@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/port/in"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/port/out"
+)
+
+type FindReferences struct {
+	workspaceRepository out.WorkspaceRepository
+	packageRepository   out.PackageRepository
+	documentRepository  out.DocumentRepository
+	yamlParser          out.YamlParser
+	configReader        out.PackwerkConfigReader
+}
+
+func NewFindReferences(
+	workspaceRepository out.WorkspaceRepository,
+	packageRepository out.PackageRepository,
+	documentRepository out.DocumentRepository,
+	yamlParser out.YamlParser,
+	configReader out.PackwerkConfigReader,
+) *FindReferences {
+	return &FindReferences{
+		workspaceRepository: workspaceRepository,
+		packageRepository:   packageRepository,
+		documentRepository:  documentRepository,
+		yamlParser:          yamlParser,
+		configReader:        configReader,
+	}
+}
+
+// References lists the package.yml dependencies and package_todo.yml entries pointing at a pack.
+// The pack is the one named under the cursor, or else the pack the package.yml belongs to.
+func (f *FindReferences) References(uri string, position domain.Position, includeDeclaration bool) ([]domain.Location, error) {
+	document, err := f.documentRepository.GetDocument(uri)
+	if err != nil {
+		return nil, err
+	}
+	if !document.IsPackageConfig() {
+		return []domain.Location{}, nil
+	}
+
+	workspace, err := f.workspaceRepository.GetWorkspace()
+	if err != nil {
+		return nil, err
+	}
+	packageSet, err := f.packageRepository.GetPackageSet()
+	if err != nil {
+		return nil, err
+	}
+
+	target := packageSet.PackageOf(workspace.StripRootUri(uri))
+	if root, err := f.yamlParser.Parse(document.Text); err == nil {
+		for _, key := range domain.PackageReferenceKeys {
+			if item := root.Get(key).ItemAt(position); item != nil {
+				pkg, ok := packageSet.Get(item.Value)
+				if !ok {
+					return []domain.Location{}, nil
+				}
+				target = pkg
+				break
+			}
+		}
+	}
+
+	locations := []domain.Location{}
+	if includeDeclaration {
+		locations = append(locations, domain.Location{URI: workspace.BuildFileUri(target.ConfigPath())})
+	}
+
+	for _, pkg := range packageSet.All() {
+		if pkg.Name == target.Name || !pkg.DependsOn(target.Name) {
+			continue
+		}
+		root, err := f.configReader.ReadPackageNode(workspace.RootPath, pkg)
+		if err != nil {
+			// A broken package.yml is reported by validation, not here
+			continue
+		}
+		for _, item := range root.Get(domain.KeyDependencies).Items() {
+			if item.Value == target.Name {
+				locations = append(locations, domain.Location{URI: workspace.BuildFileUri(pkg.ConfigPath()), Range: item.Range})
+			}
+		}
+	}
+
+	for _, pkg := range packageSet.All() {
+		entries, err := f.configReader.ReadPackageTodo(workspace.RootPath, pkg)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if entry.ReferencedPackage == target.Name {
+				locations = append(locations, domain.Location{URI: workspace.BuildFileUri(pkg.TodoPath()), Range: entry.Range})
+			}
+		}
+	}
+
+	return locations, nil
+}
+
+var _ in.FindReferences = (*FindReferences)(nil)
@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/adapter/inmemory"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/adapter/packwerk/config"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+)
+
+func TestFindReferences_References(t *testing.T) {
+	workspaceRepository, packageRepository := setupTestProject(t)
+	workspace, _ := workspaceRepository.GetWorkspace()
+	documentRepository := inmemory.NewDocumentRepository()
+	uc := NewFindReferences(workspaceRepository, packageRepository, documentRepository, config.NewYamlParser(), config.NewReader())
+
+	usersURI := workspace.BuildFileUri("packs/users/package.yml")
+	ordersURI := workspace.BuildFileUri("packs/orders/package.yml")
+	rubyURI := workspace.BuildFileUri("packs/users/app/models/user.rb")
+	todoURI := workspace.BuildFileUri("packs/users/package_todo.yml")
+	_ = documentRepository.Save(domain.NewDocument(usersURI, "enforce_dependencies: true\nlayer: product\ndependencies:\n  - packs/books\n"))
+	_ = documentRepository.Save(domain.NewDocument(ordersURI, "enforce_dependencies: true\nlayer: product\n"))
+	_ = documentRepository.Save(domain.NewDocument(rubyURI, "class User\nend\n"))
+
+	tests := []struct {
+		name               string
+		uri                string
+		position           domain.Position
+		includeDeclaration bool
+		want               []domain.Location
+	}{
+		{
+			name:     "pack name under dependencies",
+			uri:      usersURI,
+			position: domain.Position{Line: 3, Character: 8},
+			want: []domain.Location{
+				{URI: usersURI, Range: domain.Range{Start: domain.Position{Line: 3, Character: 4}, End: domain.Position{Line: 3, Character: 15}}},
+				{URI: todoURI, Range: domain.Range{Start: domain.Position{Line: 19, Character: 6}, End: domain.Position{Line: 19, Character: 36}}},
+			},
+		},
+		{
+			name:               "current pack with declaration",
+			uri:                ordersURI,
+			position:           domain.Position{Line: 0, Character: 0},
+			includeDeclaration: true,
+			want: []domain.Location{
+				{URI: ordersURI},
+				{URI: todoURI, Range: domain.Range{Start: domain.Position{Line: 12, Character: 6}, End: domain.Position{Line: 12, Character: 36}}},
+				{URI: todoURI, Range: domain.Range{Start: domain.Position{Line: 13, Character: 6}, End: domain.Position{Line: 13, Character: 42}}},
+			},
+		},
+		{
+			name:     "pack nobody depends on",
+			uri:      usersURI,
+			position: domain.Position{Line: 1, Character: 2},
+			want:     []domain.Location{},
+		},
+		{
+			name:     "ruby file",
+			uri:      rubyURI,
+			position: domain.Position{Line: 0, Character: 2},
+			want:     []domain.Location{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := uc.References(tt.uri, tt.position, tt.includeDeclaration)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("want %d locations, got %+v", len(tt.want), got)
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Errorf("location %d: want %+v, got %+v", i, tt.want[i], got[i])
+				}
+			}
+		})
+	}
+}
+
+func TestFindReferences_References_DocumentNotOpen(t *testing.T) {
+	workspaceRepository, packageRepository := setupTestProject(t)
+	uc := NewFindReferences(workspaceRepository, packageRepository, inmemory.NewDocumentRepository(), config.NewYamlParser(), config.NewReader())
+
+	if _, err := uc.References("file:///not/open/package.yml", domain.Position{}, false); err == nil {
+		t.Error("expected error for a document that is not open")
+	}
+}
@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/port/in"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/port/out"
+)
+
+type ListCodeLenses struct {
+	workspaceRepository out.WorkspaceRepository
+	packageRepository   out.PackageRepository
+	violationRepository out.ViolationRepository
+	settingsRepository  out.SettingsRepository
+	configReader        out.PackwerkConfigReader
+	fileSystem          out.FileSystem
+	versionControl      out.VersionControl
+}
+
+func NewListCodeLenses(
+	workspaceRepository out.WorkspaceRepository,
+	packageRepository out.PackageRepository,
+	violationRepository out.ViolationRepository,
+	settingsRepository out.SettingsRepository,
+	configReader out.PackwerkConfigReader,
+	fileSystem out.FileSystem,
+	versionControl out.VersionControl,
+) *ListCodeLenses {
+	return &ListCodeLenses{
+		workspaceRepository: workspaceRepository,
+		packageRepository:   packageRepository,
+		violationRepository: violationRepository,
+		settingsRepository:  settingsRepository,
+		configReader:        configReader,
+		fileSystem:          fileSystem,
+		versionControl:      versionControl,
+	}
+}
+
+// CodeLenses summarizes, at the top of a package.yml, the violations made by the pack
+// in the latest checks that the diagnostics report and the entries of its package_todo.yml.
+func (l *ListCodeLenses) CodeLenses(uri string) ([]domain.CodeLens, error) {
+	if !domain.NewDocument(uri, "").IsPackageConfig() {
+		return []domain.CodeLens{}, nil
+	}
+
+	workspace, err := l.workspaceRepository.GetWorkspace()
+	if err != nil {
+		return nil, err
+	}
+	packageSet, err := l.packageRepository.GetPackageSet()
+	if err != nil {
+		return nil, err
+	}
+	pkg := packageSet.PackageOf(workspace.StripRootUri(uri))
+	if workspace.BuildFileUri(pkg.ConfigPath()) != uri {
+		// Not listed in package_paths, so not a pack
+		return []domain.CodeLens{}, nil
+	}
+
+	violations, err := l.violationRepository.GetViolations()
+	if err != nil {
+		return nil, err
+	}
+	reported, _, err := reportedViolations(context.Background(), l.settingsRepository, l.fileSystem, l.versionControl, workspace, violations)
+	if err != nil {
+		return nil, err
+	}
+	todoEntries, err := l.configReader.ReadPackageTodo(workspace.RootPath, pkg)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int)
+	var violationLocations []domain.Location
+	for _, v := range reported {
+		if packageSet.PackageOf(v.File).Name != pkg.Name {
+			continue
+		}
+		counts[v.Kind()]++
+		violationLocations = append(violationLocations, domain.Location{URI: workspace.BuildFileUri(v.File), Range: v.Range()})
+	}
+	slices.SortStableFunc(violationLocations, func(a, b domain.Location) int {
+		return strings.Compare(a.URI, b.URI)
+	})
+
+	titles := make([]string, 0, len(counts)+1)
+	for _, kind := range slices.Sorted(maps.Keys(counts)) {
+		titles = append(titles, pluralize(counts[kind], kind+" violation", kind+" violations"))
+	}
+	if len(counts) == 0 {
+		titles = append(titles, pluralize(0, "violation", "violations"))
+	}
+	titles = append(titles, pluralize(len(todoEntries), "todo entry", "todo entries"))
+
+	locations := violationLocations
+	todoURI := workspace.BuildFileUri(pkg.TodoPath())
+	for _, entry := range todoEntries {
+		locations = append(locations, domain.Location{URI: todoURI, Range: entry.Range})
+	}
+
+	return []domain.CodeLens{{
+		Title:     strings.Join(titles, " · "),
+		Locations: locations,
+	}}, nil
+}
+
+var _ in.ListCodeLenses = (*ListCodeLenses)(nil)
@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/adapter/inmemory"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/adapter/packwerk/config"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+)
+
+func TestListCodeLenses_CodeLenses(t *testing.T) {
+	workspaceRepository, packageRepository := setupTestProject(t)
+	workspace, _ := workspaceRepository.GetWorkspace()
+	violationRepository := inmemory.NewViolationRepository()
+	_ = violationRepository.ReplaceAll([]domain.Violation{
+		{File: "packs/users/app/models/user.rb", Line: 3, Character: 2, Type: "Dependency violation"},
+		{File: "packs/users/app/models/user.rb", Line: 8, Character: 2, Type: "Privacy violation"},
+		{File: "packs/users/app/controllers/users_controller.rb", Line: 20, Character: 4, Type: "Dependency violation"},
+		{File: "packs/books/app/models/book.rb", Line: 1, Character: 0, Type: "Dependency violation"},
+	})
+	uc := NewListCodeLenses(workspaceRepository, packageRepository, violationRepository, inmemory.NewSettingsRepository(), config.NewReader(), &fakeFileSystem{files: map[string]string{}}, &fakeVersionControl{})
+
+	tests := []struct {
+		name          string
+		uri           string
+		wantTitle     string
+		wantLocations []string
+	}{
+		{
+			name:      "violations and todo entries",
+			uri:       workspace.BuildFileUri("packs/users/package.yml"),
+			wantTitle: "2 dependency violations · 1 privacy violation · 3 todo entries",
+			wantLocations: []string{
+				workspace.BuildFileUri("packs/users/app/controllers/users_controller.rb"),
+				workspace.BuildFileUri("packs/users/app/models/user.rb"),
+				workspace.BuildFileUri("packs/users/app/models/user.rb"),
+				workspace.BuildFileUri("packs/users/package_todo.yml"),
+				workspace.BuildFileUri("packs/users/package_todo.yml"),
+				workspace.BuildFileUri("packs/users/package_todo.yml"),
+			},
+		},
+		{
+			name:          "no package_todo.yml",
+			uri:           workspace.BuildFileUri("packs/books/package.yml"),
+			wantTitle:     "1 dependency violation · 0 todo entries",
+			wantLocations: []string{workspace.BuildFileUri("packs/books/app/models/book.rb")},
+		},
+		{
+			name:      "nothing recorded",
+			uri:       workspace.BuildFileUri("packs/orders/package.yml"),
+			wantTitle: "0 violations · 0 todo entries",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := uc.CodeLenses(tt.uri)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(got) != 1 {
+				t.Fatalf("want 1 code lens, got %d", len(got))
+			}
+			if got[0].Title != tt.wantTitle {
+				t.Errorf("want title %q, got %q", tt.wantTitle, got[0].Title)
+			}
+			if len(got[0].Locations) != len(tt.wantLocations) {
+				t.Fatalf("want %d locations, got %+v", len(tt.wantLocations), got[0].Locations)
+			}
+			for i, uri := range tt.wantLocations {
+				if got[0].Locations[i].URI != uri {
+					t.Errorf("location %d: want %q, got %q", i, uri, got[0].Locations[i].URI)
+				}
+			}
+		})
+	}
+}
+
+func TestListCodeLenses_SettingsRules(t *testing.T) {
+	workspaceRepository, packageRepository := setupTestProject(t)
+	workspace, _ := workspaceRepository.GetWorkspace()
+	violationRepository := inmemory.NewViolationRepository()
+	_ = violationRepository.ReplaceAll([]domain.Violation{
+		{File: "packs/users/app/models/user.rb", Line: 3, Character: 2, Type: "Dependency violation"},
+		{File: "packs/users/app/models/user.rb", Line: 8, Character: 2, Type: "Privacy violation"},
+		{File: "packs/users/app/controllers/users_controller.rb", Line: 20, Character: 4, Type: "Dependency violation"},
+		{File: "packs/users/spec/models/user_spec.rb", Line: 5, Character: 0, Type: "Dependency violation"},
+	})
+	settingsRepository := inmemory.NewSettingsRepository()
+	settings := domain.NewSettings().WithSeverity("privacy", domain.SeverityIgnore)
+	settings.ExcludePaths = []string{"packs/*/spec/**"}
+	settings.DiffBase = "HEAD"
+	_ = settingsRepository.Save(settings)
+	changes := domain.NewChangedLines()
+	changes.Add("packs/users/app/models/user.rb", 1, 10)
+	changes.Add("packs/users/spec/models/user_spec.rb", 1, 10)
+	uc := NewListCodeLenses(workspaceRepository, packageRepository, violationRepository, settingsRepository, config.NewReader(), &fakeFileSystem{files: map[string]string{}}, &fakeVersionControl{changes: changes})
+
+	got, err := uc.CodeLenses(workspace.BuildFileUri("packs/users/package.yml"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("want 1 code lens, got %d", len(got))
+	}
+	// Only the dependency violation on a changed line of user.rb is reported
+	if want := "1 dependency violation · 3 todo entries"; got[0].Title != want {
+		t.Errorf("want title %q, got %q", want, got[0].Title)
+	}
+	if len(got[0].Locations) == 0 || got[0].Locations[0].URI != workspace.BuildFileUri("packs/users/app/models/user.rb") {
+		t.Errorf("want the violation of user.rb first, got %+v", got[0].Locations)
+	}
+}
+
+func TestListCodeLenses_OtherFiles(t *testing.T) {
+	workspaceRepository, packageRepository := setupTestProject(t)
+	workspace, _ := workspaceRepository.GetWorkspace()
+	uc := NewListCodeLenses(workspaceRepository, packageRepository, inmemory.NewViolationRepository(), inmemory.NewSettingsRepository(), config.NewReader(), &fakeFileSystem{files: map[string]string{}}, &fakeVersionControl{})
+
+	for _, path := range []string{"packs/users/app/models/user.rb", "packwerk.yml", "lib/tools/package.yml"} {
+		got, err := uc.CodeLenses(workspace.BuildFileUri(path))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(got) != 0 {
+			t.Errorf("%s: want no code lens, got %+v", path, got)
+		}
+	}
+}
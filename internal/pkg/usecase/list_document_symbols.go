@@ -0,0 +1,132 @@
+package usecase
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/port/in"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/port/out"
+)
+
+type ListDocumentSymbols struct {
+	documentRepository out.DocumentRepository
+	yamlParser         out.YamlParser
+}
+
+func NewListDocumentSymbols(documentRepository out.DocumentRepository, yamlParser out.YamlParser) *ListDocumentSymbols {
+	return &ListDocumentSymbols{
+		documentRepository: documentRepository,
+		yamlParser:         yamlParser,
+	}
+}
+
+// DocumentSymbols builds the outline of a package.yml or package_todo.yml.
+func (l *ListDocumentSymbols) DocumentSymbols(uri string) ([]domain.DocumentSymbol, error) {
+	document, err := l.documentRepository.GetDocument(uri)
+	if err != nil {
+		return nil, err
+	}
+	if !document.IsPackageConfig() && !document.IsPackageTodo() {
+		return []domain.DocumentSymbol{}, nil
+	}
+
+	root, err := l.yamlParser.Parse(document.Text)
+	if err != nil || root.Kind != domain.YamlMapping {
+		// The document is being edited, keep the outline empty until it parses again
+		return []domain.DocumentSymbol{}, nil
+	}
+
+	if document.IsPackageTodo() {
+		return packageTodoSymbols(root), nil
+	}
+	return packageConfigSymbols(root), nil
+}
+
+// packageConfigSymbols lists every top-level key, with pack references as children.
+func packageConfigSymbols(root *domain.YamlNode) []domain.DocumentSymbol {
+	symbols := make([]domain.DocumentSymbol, 0, len(root.Children))
+	for _, node := range root.Children {
+		symbol := domain.DocumentSymbol{
+			Name:           node.Key,
+			Detail:         node.ScalarValue(),
+			Kind:           domain.SymbolKindKey,
+			Range:          node.Extent(),
+			SelectionRange: node.KeyRange,
+		}
+		if node.Kind == domain.YamlSequence {
+			symbol.Kind = domain.SymbolKindArray
+			for _, item := range node.Items() {
+				// An item being typed has no value yet, and clients reject symbols without a name
+				if item.Value == "" {
+					continue
+				}
+				child := domain.DocumentSymbol{
+					Name:           item.Value,
+					Kind:           domain.SymbolKindKey,
+					Range:          item.Range,
+					SelectionRange: item.Range,
+				}
+				if slices.Contains(domain.PackageReferenceKeys, node.Key) {
+					child.Kind = domain.SymbolKindPackage
+				}
+				symbol.Children = append(symbol.Children, child)
+			}
+			symbol.Detail = pluralize(len(symbol.Children), "entry", "entries")
+		}
+		symbols = append(symbols, symbol)
+	}
+	return symbols
+}
+
+// packageTodoSymbols lists the referenced packs, their constants and the files referencing them.
+func packageTodoSymbols(root *domain.YamlNode) []domain.DocumentSymbol {
+	symbols := make([]domain.DocumentSymbol, 0, len(root.Children))
+	for _, packNode := range root.Children {
+		pack := domain.DocumentSymbol{
+			Name:           packNode.Key,
+			Kind:           domain.SymbolKindNamespace,
+			Range:          packNode.Extent(),
+			SelectionRange: packNode.KeyRange,
+		}
+		for _, constantNode := range packNode.Children {
+			violations := make([]string, 0)
+			for _, item := range constantNode.Get("violations").Items() {
+				violations = append(violations, item.Value)
+			}
+
+			constant := domain.DocumentSymbol{
+				Name:           constantNode.Key,
+				Kind:           domain.SymbolKindConstant,
+				Range:          constantNode.Extent(),
+				SelectionRange: constantNode.KeyRange,
+			}
+			for _, file := range constantNode.Get("files").Items() {
+				if file.Value == "" {
+					continue
+				}
+				constant.Children = append(constant.Children, domain.DocumentSymbol{
+					Name:           file.Value,
+					Kind:           domain.SymbolKindFile,
+					Range:          file.Range,
+					SelectionRange: file.Range,
+				})
+			}
+			constant.Detail = fmt.Sprintf("%s · %s", strings.Join(violations, ", "), pluralize(len(constant.Children), "file", "files"))
+			pack.Children = append(pack.Children, constant)
+		}
+		pack.Detail = pluralize(len(pack.Children), "constant", "constants")
+		symbols = append(symbols, pack)
+	}
+	return symbols
+}
+
+func pluralize(count int, singular string, plural string) string {
+	if count == 1 {
+		return fmt.Sprintf("%d %s", count, singular)
+	}
+	return fmt.Sprintf("%d %s", count, plural)
+}
+
+var _ in.ListDocumentSymbols = (*ListDocumentSymbols)(nil)
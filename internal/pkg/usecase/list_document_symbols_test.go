@@ -0,0 +1,158 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/adapter/inmemory"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/adapter/packwerk/config"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+)
+
+const testPackageTodoYml = `# This file contains a list of dependencies that are not part of the long term plan.
+---
+packs/books:
+  "::Book":
+    violations:
+    - dependency
+    - privacy
+    files:
+    - packs/users/app/models/user.rb
+    - packs/users/app/controllers/users_controller.rb
+packs/orders:
+  "::Order":
+    violations:
+    - dependency
+    files:
+    - packs/users/app/models/user.rb
+`
+
+func TestListDocumentSymbols_PackageTodo(t *testing.T) {
+	documentRepository := inmemory.NewDocumentRepository()
+	uc := NewListDocumentSymbols(documentRepository, config.NewYamlParser())
+
+	uri := "file:///project/packs/users/package_todo.yml"
+	_ = documentRepository.Save(domain.NewDocument(uri, testPackageTodoYml))
+
+	symbols, err := uc.DocumentSymbols(uri)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(symbols) != 2 {
+		t.Fatalf("want 2 packs, got %d", len(symbols))
+	}
+
+	books := symbols[0]
+	if books.Name != "packs/books" || books.Kind != domain.SymbolKindNamespace || books.Detail != "1 constant" {
+		t.Errorf("unexpected pack symbol: %+v", books)
+	}
+	if books.Range.Start.Line != 2 || books.Range.End.Line != 9 {
+		t.Errorf("unexpected pack range: %+v", books.Range)
+	}
+	if len(books.Children) != 1 {
+		t.Fatalf("want 1 constant, got %d", len(books.Children))
+	}
+
+	book := books.Children[0]
+	if book.Name != "::Book" || book.Kind != domain.SymbolKindConstant {
+		t.Errorf("unexpected constant symbol: %+v", book)
+	}
+	if book.Detail != "dependency, privacy · 2 files" {
+		t.Errorf("unexpected constant detail: %q", book.Detail)
+	}
+	if len(book.Children) != 2 || book.Children[0].Name != "packs/users/app/models/user.rb" || book.Children[0].Kind != domain.SymbolKindFile {
+		t.Errorf("unexpected file symbols: %+v", book.Children)
+	}
+
+	if got := symbols[1].Children[0].Detail; got != "dependency · 1 file" {
+		t.Errorf("unexpected constant detail: %q", got)
+	}
+}
+
+func TestListDocumentSymbols_PackageConfig(t *testing.T) {
+	documentRepository := inmemory.NewDocumentRepository()
+	uc := NewListDocumentSymbols(documentRepository, config.NewYamlParser())
+
+	uri := "file:///project/packs/users/package.yml"
+	_ = documentRepository.Save(domain.NewDocument(uri, testPackageYml))
+
+	symbols, err := uc.DocumentSymbols(uri)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []struct {
+		name     string
+		kind     domain.SymbolKind
+		detail   string
+		children int
+	}{
+		{"enforce_dependencies", domain.SymbolKindKey, "true", 0},
+		{"dependencies", domain.SymbolKindArray, "2 entries", 2},
+		{"visible_to", domain.SymbolKindArray, "1 entry", 1},
+	}
+	if len(symbols) != len(want) {
+		t.Fatalf("want %d symbols, got %d", len(want), len(symbols))
+	}
+	for i, w := range want {
+		got := symbols[i]
+		if got.Name != w.name || got.Kind != w.kind || got.Detail != w.detail || len(got.Children) != w.children {
+			t.Errorf("symbol %d: want %+v, got %+v", i, w, got)
+		}
+	}
+	if dependency := symbols[1].Children[0]; dependency.Name != "packs/books" || dependency.Kind != domain.SymbolKindPackage {
+		t.Errorf("unexpected dependency symbol: %+v", dependency)
+	}
+}
+
+func TestListDocumentSymbols_EmptyItems(t *testing.T) {
+	documentRepository := inmemory.NewDocumentRepository()
+	uc := NewListDocumentSymbols(documentRepository, config.NewYamlParser())
+
+	// The user is still typing the entries
+	uri := "file:///project/packs/users/package.yml"
+	_ = documentRepository.Save(domain.NewDocument(uri, "dependencies:\n  - packs/books\n  - \n"))
+
+	symbols, err := uc.DocumentSymbols(uri)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(symbols) != 1 {
+		t.Fatalf("want 1 symbol, got %d", len(symbols))
+	}
+	if got := symbols[0]; got.Detail != "1 entry" || len(got.Children) != 1 || got.Children[0].Name != "packs/books" {
+		t.Errorf("want the empty item skipped, got %+v", got)
+	}
+
+	todoUri := "file:///project/packs/users/package_todo.yml"
+	_ = documentRepository.Save(domain.NewDocument(todoUri, "packs/books:\n  \"::Book\":\n    violations:\n    - dependency\n    files:\n    - \n"))
+	symbols, err = uc.DocumentSymbols(todoUri)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(symbols) != 1 || len(symbols[0].Children) != 1 {
+		t.Fatalf("want 1 pack with 1 constant, got %+v", symbols)
+	}
+	if got := symbols[0].Children[0]; got.Detail != "dependency · 0 files" || len(got.Children) != 0 {
+		t.Errorf("want the empty file skipped, got %+v", got)
+	}
+}
+
+func TestListDocumentSymbols_OtherDocuments(t *testing.T) {
+	documentRepository := inmemory.NewDocumentRepository()
+	uc := NewListDocumentSymbols(documentRepository, config.NewYamlParser())
+
+	rubyURI := "file:///project/app/models/user.rb"
+	brokenURI := "file:///project/packs/users/package.yml"
+	_ = documentRepository.Save(domain.NewDocument(rubyURI, "class User\nend\n"))
+	_ = documentRepository.Save(domain.NewDocument(brokenURI, "dependencies: [\n"))
+
+	for _, uri := range []string{rubyURI, brokenURI} {
+		symbols, err := uc.DocumentSymbols(uri)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(symbols) != 0 {
+			t.Errorf("%s: want no symbols, got %+v", uri, symbols)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/port/in"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/port/out"
+)
+
+type LoadPackages struct {
+	workspaceRepository out.WorkspaceRepository
+	packageRepository   out.PackageRepository
+	configReader        out.PackwerkConfigReader
+}
+
+func NewLoadPackages(workspaceRepository out.WorkspaceRepository, packageRepository out.PackageRepository, configReader out.PackwerkConfigReader) *LoadPackages {
+	return &LoadPackages{
+		workspaceRepository: workspaceRepository,
+		packageRepository:   packageRepository,
+		configReader:        configReader,
+	}
+}
+
+func (l *LoadPackages) Load() error {
+	workspace, err := l.workspaceRepository.GetWorkspace()
+	if err != nil {
+		return err
+	}
+
+	config, err := l.configReader.ReadConfig(workspace.RootPath)
+	if err != nil {
+		return err
+	}
+
+	packages, err := l.configReader.ReadPackages(workspace.RootPath, config)
+	if err != nil {
+		return err
+	}
+
+	packageSet := domain.NewPackageSet(config, packages)
+	for _, pkg := range packageSet.All() {
+		files, err := l.configReader.ReadPublicFiles(workspace.RootPath, pkg)
+		if err != nil {
+			return err
+		}
+		constants := make([]domain.PublicConstant, 0, len(files))
+		for _, file := range files {
+			constants = append(constants, domain.NewPublicConstant(pkg.PublicDirectory(), file))
+		}
+		packageSet.SetPublicConstants(pkg.Name, constants)
+	}
+
+	return l.packageRepository.Save(packageSet)
+}
+
+// Refresh reloads the packs when one of the changed files is a config file
+// or lies in a public folder, which are the files the registry indexes.
+func (l *LoadPackages) Refresh(uris ...string) error {
+	workspace, err := l.workspaceRepository.GetWorkspace()
+	if err != nil {
+		return err
+	}
+
+	packageSet, err := l.packageRepository.GetPackageSet()
+	if err != nil {
+		return l.Load()
+	}
+
+	for _, uri := range uris {
+		filePath := workspace.StripRootUri(uri)
+		if domain.IsConfigFile(filePath) || packageSet.IsPublicFile(filePath) {
+			return l.Load()
+		}
+	}
+	return nil
+}
+
+var _ in.LoadPackages = (*LoadPackages)(nil)
@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/adapter/inmemory"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/adapter/packwerk/config"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+)
+
+// setupTestProject saves a workspace pointing at testdata/project and loads its packs
+func setupTestProject(t *testing.T) (*inmemory.WorkspaceRepository, *inmemory.PackageRepository) {
+	t.Helper()
+	rootPath, err := filepath.Abs("./testdata/project")
+	if err != nil {
+		t.Fatalf("failed to resolve project path: %v", err)
+	}
+	workspaceRepository := inmemory.NewWorkspaceRepository()
+	if err := workspaceRepository.Save(domain.NewWorkspace("file://"+rootPath, rootPath)); err != nil {
+		t.Fatalf("failed to save workspace: %v", err)
+	}
+	packageRepository := inmemory.NewPackageRepository()
+	if err := NewLoadPackages(workspaceRepository, packageRepository, config.NewReader()).Load(); err != nil {
+		t.Fatalf("failed to load packages: %v", err)
+	}
+	return workspaceRepository, packageRepository
+}
+
+func TestLoadPackages_Load(t *testing.T) {
+	_, packageRepository := setupTestProject(t)
+
+	packageSet, err := packageRepository.GetPackageSet()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{".", "packs/books", "packs/orders", "packs/users"}
+	all := packageSet.All()
+	if len(all) != len(want) {
+		t.Fatalf("want %d packages, got %d", len(want), len(all))
+	}
+	for i, name := range want {
+		if all[i].Name != name {
+			t.Errorf("package %d: want %q, got %q", i, name, all[i].Name)
+		}
+	}
+	if got := packageSet.Config.Layers; len(got) != 2 || got[0] != "product" {
+		t.Errorf("unexpected layers: %v", got)
+	}
+}
+
+func TestLoadPackages_Load_WithoutWorkspace(t *testing.T) {
+	uc := NewLoadPackages(inmemory.NewWorkspaceRepository(), inmemory.NewPackageRepository(), config.NewReader())
+	if err := uc.Load(); err == nil {
+		t.Error("expected error when workspace is not created")
+	}
+}
+
+func TestLoadPackages_Load_IndexesPublicConstants(t *testing.T) {
+	_, packageRepository := setupTestProject(t)
+
+	packageSet, err := packageRepository.GetPackageSet()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	constants := packageSet.PublicConstants("packs/books")
+	if len(constants) != 1 || constants[0].Name != "Books::Api" || constants[0].File != "packs/books/app/public/books/api.rb" {
+		t.Errorf("unexpected public constants: %+v", constants)
+	}
+}
+
+func TestLoadPackages_Refresh(t *testing.T) {
+	workspaceRepository, packageRepository := setupTestProject(t)
+	workspace, _ := workspaceRepository.GetWorkspace()
+	uc := NewLoadPackages(workspaceRepository, packageRepository, config.NewReader())
+
+	tests := []struct {
+		name       string
+		uri        string
+		wantReload bool
+	}{
+		{"package.yml", workspace.BuildFileUri("packs/books/package.yml"), true},
+		{"public file", workspace.BuildFileUri("packs/books/app/public/books/api.rb"), true},
+		{"private file", workspace.BuildFileUri("packs/books/app/models/book.rb"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before, _ := packageRepository.GetPackageSet()
+			if err := uc.Refresh(tt.uri); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			after, _ := packageRepository.GetPackageSet()
+			if reloaded := before != after; reloaded != tt.wantReload {
+				t.Errorf("want reload %v, got %v", tt.wantReload, reloaded)
+			}
+		})
+	}
+}
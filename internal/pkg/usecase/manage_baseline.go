@@ -0,0 +1,145 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/port/in"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/port/out"
+)
+
+// ManageBaseline writes .wpks-ls-baseline.json, which lowers the severity of the violations known
+// when it was created, so that only new ones stand out.
+type ManageBaseline struct {
+	workspaceRepository out.WorkspaceRepository
+	violationRepository out.ViolationRepository
+	settingsRepository  out.SettingsRepository
+	fileSystem          out.FileSystem
+	packwerkRunner      out.PackwerkRunner
+}
+
+func NewManageBaseline(
+	workspaceRepository out.WorkspaceRepository,
+	violationRepository out.ViolationRepository,
+	settingsRepository out.SettingsRepository,
+	fileSystem out.FileSystem,
+	packwerkRunner out.PackwerkRunner,
+) *ManageBaseline {
+	return &ManageBaseline{
+		workspaceRepository: workspaceRepository,
+		violationRepository: violationRepository,
+		settingsRepository:  settingsRepository,
+		fileSystem:          fileSystem,
+		packwerkRunner:      packwerkRunner,
+	}
+}
+
+// CreateBaseline replaces the baseline with the violations reported now.
+// Violations that are ignored or excluded by the settings are left out.
+func (m *ManageBaseline) CreateBaseline(context context.Context) (int, error) {
+	workspace, err := m.workspaceRepository.GetWorkspace()
+	if err != nil {
+		return 0, err
+	}
+	violations, _, err := m.checkAll(context, workspace)
+	if err != nil {
+		return 0, err
+	}
+	baseline := domain.NewBaseline(violations)
+	return baseline.Len(), m.writeBaseline(workspace, baseline)
+}
+
+// RefreshBaseline keeps the violations of the baseline that are still reported.
+// New violations are not added, so that they are not hidden by mistake.
+func (m *ManageBaseline) RefreshBaseline(context context.Context) (int, error) {
+	workspace, err := m.workspaceRepository.GetWorkspace()
+	if err != nil {
+		return 0, err
+	}
+	baseline, exists, err := readBaseline(m.fileSystem, workspace)
+	if err != nil {
+		return 0, err
+	}
+	if !exists {
+		return 0, fmt.Errorf("%s does not exist, create it first", domain.BaselineFile)
+	}
+
+	violations, checked, err := m.checkAll(context, workspace)
+	if err != nil {
+		return 0, err
+	}
+	// A check finding nothing at all more likely failed than fixed every known violation
+	if len(checked) == 0 && baseline.Len() > 0 {
+		return 0, fmt.Errorf("the check reported no violations while %s lists %d, create it again to clear it", domain.BaselineFile, baseline.Len())
+	}
+	refreshed := baseline.Refresh(violations)
+	return refreshed.Len(), m.writeBaseline(workspace, refreshed)
+}
+
+// ValidateBaseline reports why .wpks-ls-baseline.json cannot be read.
+func (m *ManageBaseline) ValidateBaseline() error {
+	workspace, err := m.workspaceRepository.GetWorkspace()
+	if err != nil {
+		return err
+	}
+	_, _, err = readBaseline(m.fileSystem, workspace)
+	return err
+}
+
+// checkAll runs a full check and returns the violations the settings report, followed by every violation found.
+// The violations are stored, so that the diagnostics can be rebuilt against the new baseline.
+func (m *ManageBaseline) checkAll(context context.Context, workspace *domain.Workspace) ([]domain.Violation, []domain.Violation, error) {
+	settings, err := m.settingsRepository.GetSettings()
+	if err != nil {
+		return nil, nil, err
+	}
+	rules, err := settings.CompileRules()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	violations, err := m.packwerkRunner.RunCheckAll(context, workspace.RootPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := m.violationRepository.ReplaceAll(violations); err != nil {
+		return nil, nil, err
+	}
+
+	reported := make([]domain.Violation, 0, len(violations))
+	for _, v := range violations {
+		if _, ok := rules.Severity(v); ok {
+			reported = append(reported, v)
+		}
+	}
+	return reported, violations, nil
+}
+
+func (m *ManageBaseline) writeBaseline(workspace *domain.Workspace, baseline *domain.Baseline) error {
+	text, err := baseline.Text()
+	if err != nil {
+		return err
+	}
+	return m.fileSystem.WriteFile(baselinePath(workspace), text)
+}
+
+// readBaseline reads .wpks-ls-baseline.json. Without the file, the baseline is empty.
+func readBaseline(fileSystem out.FileSystem, workspace *domain.Workspace) (*domain.Baseline, bool, error) {
+	text, exists, err := fileSystem.ReadFile(baselinePath(workspace))
+	if err != nil || !exists {
+		return &domain.Baseline{}, false, err
+	}
+	baseline, err := domain.ParseBaseline(text)
+	if err != nil {
+		return nil, true, fmt.Errorf("invalid %s: %w", domain.BaselineFile, err)
+	}
+	return baseline, true, nil
+}
+
+func baselinePath(workspace *domain.Workspace) string {
+	return filepath.Join(workspace.RootPath, domain.BaselineFile)
+}
+
+var _ in.ManageBaseline = (*ManageBaseline)(nil)
@@ -0,0 +1,109 @@
+package usecase
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/adapter/inmemory"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/adapter/packwerk/config"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+)
+
+func TestManageBaseline(t *testing.T) {
+	baselinePath := filepath.Join(testRootPath, domain.BaselineFile)
+	fileSystem := &fakeFileSystem{files: map[string]string{}}
+	violationRepository := inmemory.NewViolationRepository()
+	settingsRepository := inmemory.NewSettingsRepository()
+	runner := &fakePackwerkRunner{output: loadTestFixture(t, "packwerk_output_multiple.txt")}
+	uc := NewManageBaseline(setupTestRepository(t), violationRepository, settingsRepository, fileSystem, runner)
+
+	if _, err := uc.RefreshBaseline(context.Background()); err == nil || !strings.Contains(err.Error(), "does not exist") {
+		t.Errorf("want an error without a baseline, got %v", err)
+	}
+
+	count, err := uc.CreateBaseline(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("want 2 violations listed, got %d", count)
+	}
+	baseline, err := domain.ParseBaseline(fileSystem.files[baselinePath])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(baseline.Entries) != 1 || baseline.Entries[0].Count != 2 || baseline.Entries[0].Constant != "::Book" {
+		t.Errorf("want ::Book listed twice, got %+v", baseline.Entries)
+	}
+	if violations, _ := violationRepository.GetViolations(); len(violations) != 2 {
+		t.Errorf("want the violations of the check stored, got %d", len(violations))
+	}
+
+	// One reference was fixed
+	runner.output = loadTestFixture(t, "packwerk_output_single.txt")
+	count, err = uc.RefreshBaseline(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("want 1 violation left, got %d", count)
+	}
+
+	// A check finding nothing does not clear the baseline
+	runner.output = "No offenses detected\n"
+	if _, err := uc.RefreshBaseline(context.Background()); err == nil || !strings.Contains(err.Error(), "no violations") {
+		t.Errorf("want an error for a suspect check, got %v", err)
+	}
+	if baseline, err := domain.ParseBaseline(fileSystem.files[baselinePath]); err != nil || baseline.Len() != 1 {
+		t.Errorf("want the baseline kept, got %v, %v", baseline, err)
+	}
+	runner.output = loadTestFixture(t, "packwerk_output_single.txt")
+
+	if err := uc.ValidateBaseline(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	fileSystem.files[baselinePath] = "{"
+	if err := uc.ValidateBaseline(); err == nil || !strings.Contains(err.Error(), "invalid "+domain.BaselineFile) {
+		t.Errorf("want an error for a broken baseline, got %v", err)
+	}
+
+	// Ignored violations are not listed
+	settings := domain.NewSettings().WithSeverity("dependency", domain.SeverityIgnore)
+	_ = settingsRepository.Save(settings)
+	if count, err := uc.CreateBaseline(context.Background()); err != nil || count != 0 {
+		t.Errorf("want an empty baseline, got %d, %v", count, err)
+	}
+}
+
+func TestManageBaseline_RebuildClearsFixedFiles(t *testing.T) {
+	workspaceRepository := setupTestRepository(t)
+	violationRepository := inmemory.NewViolationRepository()
+	settingsRepository := inmemory.NewSettingsRepository()
+	fileSystem := &fakeFileSystem{files: map[string]string{}}
+	runner := &fakePackwerkRunner{output: loadTestFixture(t, "packwerk_output_multiple.txt")}
+	diagnoser := NewDiagnoseFile(workspaceRepository, inmemory.NewPackageRepository(), violationRepository, settingsRepository, config.NewReader(), fileSystem, &fakeVersionControl{}, runner)
+	uc := NewManageBaseline(workspaceRepository, violationRepository, settingsRepository, fileSystem, runner)
+
+	if _, err := diagnoser.Diagnose(context.Background(), testURI1, testURI2); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// The full check of the baseline finds the violations of testURI1 and testURI2 fixed
+	if _, err := uc.CreateBaseline(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, err := diagnoser.Rebuild()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, uri := range []string{testURI1, testURI2} {
+		if diagnostics, ok := got[uri]; !ok || len(diagnostics) != 0 {
+			t.Errorf("want the diagnostics of the fixed %s to be cleared, got %+v", uri, diagnostics)
+		}
+	}
+	if len(got[expectedFileURI]) != 2 || !got[expectedFileURI][0].Known {
+		t.Errorf("want the violations of %s known, got %+v", expectedFileURI, got[expectedFileURI])
+	}
+}
@@ -0,0 +1,155 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/port/in"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/port/out"
+)
+
+// ManageChecker runs the packwerk maintenance tasks offered as commands.
+type ManageChecker struct {
+	workspaceRepository out.WorkspaceRepository
+	packageRepository   out.PackageRepository
+	settingsRepository  out.SettingsRepository
+	packwerkRunner      out.PackwerkRunner
+	fileSystem          out.FileSystem
+}
+
+func NewManageChecker(
+	workspaceRepository out.WorkspaceRepository,
+	packageRepository out.PackageRepository,
+	settingsRepository out.SettingsRepository,
+	packwerkRunner out.PackwerkRunner,
+	fileSystem out.FileSystem,
+) *ManageChecker {
+	return &ManageChecker{
+		workspaceRepository: workspaceRepository,
+		packageRepository:   packageRepository,
+		settingsRepository:  settingsRepository,
+		packwerkRunner:      packwerkRunner,
+		fileSystem:          fileSystem,
+	}
+}
+
+// todoSnapshot is the content of a package_todo.yml at one point in time.
+type todoSnapshot struct {
+	text   string
+	exists bool
+}
+
+// UpdateTodo runs `packwerk update-todo` and returns what it changed as an edit for the
+// user to approve. The files on disk are restored, so nothing changes until the edit is applied.
+// Checks wait meanwhile, so that they never read the files packwerk rewrote.
+// packwerk cannot update a single pack, so for a pack every other change is dropped.
+func (m *ManageChecker) UpdateTodo(context context.Context, uri string) (*domain.WorkspaceEdit, error) {
+	workspace, err := m.workspaceRepository.GetWorkspace()
+	if err != nil {
+		return nil, err
+	}
+	packageSet, err := m.packageRepository.GetPackageSet()
+	if err != nil {
+		return nil, err
+	}
+
+	packages := packageSet.All()
+	before, err := m.readTodos(workspace, packages)
+	if err != nil {
+		return nil, err
+	}
+
+	release := m.packwerkRunner.HoldChecks()
+	defer release()
+	runErr := m.packwerkRunner.RunUpdateTodo(context, workspace.RootPath)
+	after, readErr := m.readTodos(workspace, packages)
+	restoreErr := m.restoreTodos(workspace, packages, before, after)
+	release()
+	if restoreErr != nil {
+		return nil, restoreErr
+	}
+	if err := errors.Join(runErr, readErr); err != nil {
+		return nil, err
+	}
+
+	scope := packages
+	label := "Update package_todo.yml"
+	if uri != "" {
+		pkg := packageSet.PackageOf(workspace.StripRootUri(uri))
+		scope = []*domain.Package{pkg}
+		label = "Update package_todo.yml of " + pkg.Name
+	}
+
+	edit := &domain.WorkspaceEdit{Label: label}
+	for _, pkg := range scope {
+		old, updated := before[pkg.TodoPath()], after[pkg.TodoPath()]
+		change, changed := domain.NewFileChange(workspace.BuildFileUri(pkg.TodoPath()), old.text, old.exists, updated.text, updated.exists)
+		if changed {
+			edit.Changes = append(edit.Changes, change)
+		}
+	}
+	return edit, nil
+}
+
+func (m *ManageChecker) readTodos(workspace *domain.Workspace, packages []*domain.Package) (map[string]todoSnapshot, error) {
+	snapshots := make(map[string]todoSnapshot, len(packages))
+	for _, pkg := range packages {
+		text, exists, err := m.fileSystem.ReadFile(todoFilePath(workspace, pkg))
+		if err != nil {
+			return nil, err
+		}
+		snapshots[pkg.TodoPath()] = todoSnapshot{text: text, exists: exists}
+	}
+	return snapshots, nil
+}
+
+// restoreTodos puts back the files packwerk changed. after may be incomplete when reading failed.
+func (m *ManageChecker) restoreTodos(workspace *domain.Workspace, packages []*domain.Package, before, after map[string]todoSnapshot) error {
+	var errs []error
+	for _, pkg := range packages {
+		old, updated := before[pkg.TodoPath()], after[pkg.TodoPath()]
+		if after != nil && old == updated {
+			continue
+		}
+		if old.exists {
+			errs = append(errs, m.fileSystem.WriteFile(todoFilePath(workspace, pkg), old.text))
+		} else {
+			errs = append(errs, m.fileSystem.RemoveFile(todoFilePath(workspace, pkg)))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func todoFilePath(workspace *domain.Workspace, pkg *domain.Package) string {
+	return filepath.Join(workspace.RootPath, filepath.FromSlash(pkg.TodoPath()))
+}
+
+// ClearCache removes the cache_directory of packwerk.yml, or the one of the settings when set.
+func (m *ManageChecker) ClearCache() error {
+	workspace, err := m.workspaceRepository.GetWorkspace()
+	if err != nil {
+		return err
+	}
+	settings, err := m.settingsRepository.GetSettings()
+	if err != nil {
+		return err
+	}
+	if settings.CacheDirectory != "" {
+		return m.packwerkRunner.ClearCache(workspace.RootPath, settings.CacheDirectory)
+	}
+	packageSet, err := m.packageRepository.GetPackageSet()
+	if err != nil {
+		return err
+	}
+	return m.packwerkRunner.ClearCache(workspace.RootPath, packageSet.Config.CacheDirectory)
+}
+
+// Restart stops the checks in flight.
+func (m *ManageChecker) Restart() error {
+	m.packwerkRunner.Restart()
+	return nil
+}
+
+var _ in.ManageChecker = (*ManageChecker)(nil)
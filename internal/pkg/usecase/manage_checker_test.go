@@ -0,0 +1,154 @@
+package usecase
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/adapter/inmemory"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+)
+
+// fakeFileSystem keeps files in memory, so update-todo can be simulated without touching testdata
+type fakeFileSystem struct {
+	files  map[string]string
+	writes int
+}
+
+func (f *fakeFileSystem) ReadFile(path string) (string, bool, error) {
+	text, ok := f.files[path]
+	return text, ok, nil
+}
+
+func (f *fakeFileSystem) WriteFile(path string, text string) error {
+	f.writes++
+	f.files[path] = text
+	return nil
+}
+
+func (f *fakeFileSystem) RemoveFile(path string) error {
+	f.writes++
+	delete(f.files, path)
+	return nil
+}
+
+func TestManageChecker_UpdateTodo(t *testing.T) {
+	workspaceRepository, packageRepository := setupTestProject(t)
+	workspace, _ := workspaceRepository.GetWorkspace()
+	usersTodo := filepath.Join(workspace.RootPath, "packs/users/package_todo.yml")
+	ordersTodo := filepath.Join(workspace.RootPath, "packs/orders/package_todo.yml")
+	booksTodo := filepath.Join(workspace.RootPath, "packs/books/package_todo.yml")
+
+	newFileSystem := func() *fakeFileSystem {
+		return &fakeFileSystem{files: map[string]string{
+			usersTodo: "packs/books:\n  old\n",
+			booksTodo: "packs/orders:\n  stale\n",
+		}}
+	}
+	updateTodo := func(fs *fakeFileSystem) func() {
+		return func() {
+			fs.files[usersTodo] = "packs/books:\n  new\n"
+			fs.files[ordersTodo] = "packs/books:\n  created\n"
+			delete(fs.files, booksTodo)
+		}
+	}
+
+	t.Run("workspace", func(t *testing.T) {
+		fs := newFileSystem()
+		runner := &fakePackwerkRunner{onUpdateTodo: updateTodo(fs)}
+		uc := NewManageChecker(workspaceRepository, packageRepository, inmemory.NewSettingsRepository(), runner, fs)
+
+		edit, err := uc.UpdateTodo(context.Background(), "")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		want := []domain.FileChange{
+			{URI: workspace.BuildFileUri("packs/books/package_todo.yml"), Kind: domain.FileChangeDelete, Before: "packs/orders:\n  stale\n"},
+			{URI: workspace.BuildFileUri("packs/orders/package_todo.yml"), Kind: domain.FileChangeCreate, After: "packs/books:\n  created\n"},
+			{URI: workspace.BuildFileUri("packs/users/package_todo.yml"), Kind: domain.FileChangeModify, Before: "packs/books:\n  old\n", After: "packs/books:\n  new\n"},
+		}
+		if edit.Label != "Update package_todo.yml" || len(edit.Changes) != len(want) {
+			t.Fatalf("unexpected edit: %+v", edit)
+		}
+		for i := range want {
+			if edit.Changes[i] != want[i] {
+				t.Errorf("change %d: want %+v, got %+v", i, want[i], edit.Changes[i])
+			}
+		}
+
+		// The files on disk are left as they were
+		if len(fs.files) != 2 || fs.files[usersTodo] != "packs/books:\n  old\n" || fs.files[booksTodo] != "packs/orders:\n  stale\n" {
+			t.Errorf("expected the files to be restored, got %+v", fs.files)
+		}
+		// Checks cannot read the rewritten files
+		if !runner.heldTodo || runner.holding {
+			t.Errorf("expected the checks held during update-todo only, held=%v holding=%v", runner.heldTodo, runner.holding)
+		}
+	})
+
+	t.Run("single pack", func(t *testing.T) {
+		fs := newFileSystem()
+		uc := NewManageChecker(workspaceRepository, packageRepository, inmemory.NewSettingsRepository(), &fakePackwerkRunner{onUpdateTodo: updateTodo(fs)}, fs)
+
+		edit, err := uc.UpdateTodo(context.Background(), workspace.BuildFileUri("packs/users/app/models/user.rb"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if edit.Label != "Update package_todo.yml of packs/users" || len(edit.Changes) != 1 || edit.Changes[0].URI != workspace.BuildFileUri("packs/users/package_todo.yml") {
+			t.Errorf("unexpected edit: %+v", edit)
+		}
+		if fs.files[booksTodo] != "packs/orders:\n  stale\n" {
+			t.Errorf("expected changes outside the pack to be restored, got %+v", fs.files)
+		}
+	})
+
+	t.Run("nothing to update", func(t *testing.T) {
+		fs := newFileSystem()
+		uc := NewManageChecker(workspaceRepository, packageRepository, inmemory.NewSettingsRepository(), &fakePackwerkRunner{}, fs)
+
+		edit, err := uc.UpdateTodo(context.Background(), "")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !edit.IsEmpty() {
+			t.Errorf("want an empty edit, got %+v", edit)
+		}
+		if fs.writes != 0 {
+			t.Errorf("want unchanged files to be left alone, got %d writes", fs.writes)
+		}
+	})
+}
+
+func TestManageChecker(t *testing.T) {
+	workspaceRepository, packageRepository := setupTestProject(t)
+	workspace, _ := workspaceRepository.GetWorkspace()
+	runner := &fakePackwerkRunner{}
+	uc := NewManageChecker(workspaceRepository, packageRepository, inmemory.NewSettingsRepository(), runner, &fakeFileSystem{files: map[string]string{}})
+
+	if err := uc.ClearCache(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := filepath.Join(workspace.RootPath, "tmp/cache/packwerk"); runner.clearedCache != want {
+		t.Errorf("want cache %q to be cleared, got %q", want, runner.clearedCache)
+	}
+
+	settingsRepository := inmemory.NewSettingsRepository()
+	settings := domain.NewSettings()
+	settings.CacheDirectory = "tmp/packwerk"
+	_ = settingsRepository.Save(settings)
+	uc = NewManageChecker(workspaceRepository, packageRepository, settingsRepository, runner, &fakeFileSystem{files: map[string]string{}})
+	if err := uc.ClearCache(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := filepath.Join(workspace.RootPath, "tmp/packwerk"); runner.clearedCache != want {
+		t.Errorf("want the cache directory of the settings %q to be cleared, got %q", want, runner.clearedCache)
+	}
+
+	if err := uc.Restart(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !runner.restarted {
+		t.Error("expected the runner to restart")
+	}
+}
@@ -0,0 +1,33 @@
+package usecase
+
+import (
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/port/in"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/port/out"
+)
+
+type ResolvePackage struct {
+	workspaceRepository out.WorkspaceRepository
+	packageRepository   out.PackageRepository
+}
+
+func NewResolvePackage(workspaceRepository out.WorkspaceRepository, packageRepository out.PackageRepository) *ResolvePackage {
+	return &ResolvePackage{workspaceRepository: workspaceRepository, packageRepository: packageRepository}
+}
+
+// Resolve returns the pack owning the file, whether or not the file has violations.
+func (r *ResolvePackage) Resolve(uri string) (*domain.Package, error) {
+	workspace, err := r.workspaceRepository.GetWorkspace()
+	if err != nil {
+		return nil, err
+	}
+
+	packageSet, err := r.packageRepository.GetPackageSet()
+	if err != nil {
+		return nil, err
+	}
+
+	return packageSet.PackageOf(workspace.StripRootUri(uri)), nil
+}
+
+var _ in.ResolvePackage = (*ResolvePackage)(nil)
@@ -0,0 +1,30 @@
+package usecase
+
+import "testing"
+
+func TestResolvePackage_Resolve(t *testing.T) {
+	workspaceRepository, packageRepository := setupTestProject(t)
+	workspace, _ := workspaceRepository.GetWorkspace()
+	uc := NewResolvePackage(workspaceRepository, packageRepository)
+
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{"file in pack", "packs/books/app/models/book.rb", "packs/books"},
+		{"package.yml", "packs/users/package.yml", "packs/users"},
+		{"file outside packs", "config/routes.rb", "."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := uc.Resolve(workspace.BuildFileUri(tt.path))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.Name != tt.want {
+				t.Errorf("want %q, got %q", tt.want, got.Name)
+			}
+		})
+	}
+}
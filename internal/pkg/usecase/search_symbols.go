@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"strings"
+
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/port/in"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/port/out"
+)
+
+type SearchSymbols struct {
+	workspaceRepository out.WorkspaceRepository
+	packageRepository   out.PackageRepository
+}
+
+func NewSearchSymbols(workspaceRepository out.WorkspaceRepository, packageRepository out.PackageRepository) *SearchSymbols {
+	return &SearchSymbols{workspaceRepository: workspaceRepository, packageRepository: packageRepository}
+}
+
+// Search returns the packs and their public constants matching the query.
+func (s *SearchSymbols) Search(query string) ([]domain.Symbol, error) {
+	workspace, err := s.workspaceRepository.GetWorkspace()
+	if err != nil {
+		return nil, err
+	}
+	packageSet, err := s.packageRepository.GetPackageSet()
+	if err != nil {
+		return nil, err
+	}
+
+	symbols := []domain.Symbol{}
+	for _, pkg := range packageSet.All() {
+		if matchesQuery(pkg.Name, query) {
+			symbols = append(symbols, domain.Symbol{
+				Name:     pkg.Name,
+				Kind:     domain.SymbolKindPackage,
+				Location: domain.Location{URI: workspace.BuildFileUri(pkg.ConfigPath())},
+			})
+		}
+		for _, constant := range packageSet.PublicConstants(pkg.Name) {
+			if matchesQuery(constant.Name, query) {
+				symbols = append(symbols, domain.Symbol{
+					Name:          constant.Name,
+					Kind:          domain.SymbolKindConstant,
+					Location:      domain.Location{URI: workspace.BuildFileUri(constant.File)},
+					ContainerName: pkg.Name,
+				})
+			}
+		}
+	}
+
+	return symbols, nil
+}
+
+// matchesQuery reports whether the characters of the query appear in order in the name, ignoring case.
+func matchesQuery(name string, query string) bool {
+	name = strings.ToLower(name)
+	for _, r := range strings.ToLower(query) {
+		index := strings.IndexRune(name, r)
+		if index < 0 {
+			return false
+		}
+		name = name[index+1:]
+	}
+	return true
+}
+
+var _ in.SearchSymbols = (*SearchSymbols)(nil)
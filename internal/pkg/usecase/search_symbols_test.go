@@ -0,0 +1,60 @@
+package usecase
+
+import "testing"
+
+func TestSearchSymbols_Search(t *testing.T) {
+	workspaceRepository, packageRepository := setupTestProject(t)
+	workspace, _ := workspaceRepository.GetWorkspace()
+	uc := NewSearchSymbols(workspaceRepository, packageRepository)
+
+	tests := []struct {
+		name      string
+		query     string
+		wantNames []string
+		wantURIs  []string
+	}{
+		{
+			name:      "empty query returns everything",
+			query:     "",
+			wantNames: []string{".", "packs/books", "Books::Api", "packs/orders", "packs/users"},
+		},
+		{
+			name:      "fuzzy match",
+			query:     "pbks",
+			wantNames: []string{"packs/books"},
+			wantURIs:  []string{workspace.BuildFileUri("packs/books/package.yml")},
+		},
+		{
+			name:      "public constant",
+			query:     "books::api",
+			wantNames: []string{"Books::Api"},
+			wantURIs:  []string{workspace.BuildFileUri("packs/books/app/public/books/api.rb")},
+		},
+		{
+			name:      "no match",
+			query:     "zzz",
+			wantNames: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := uc.Search(tt.query)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(got) != len(tt.wantNames) {
+				t.Fatalf("want %v, got %+v", tt.wantNames, got)
+			}
+			for i, name := range tt.wantNames {
+				if got[i].Name != name {
+					t.Errorf("symbol %d: want %q, got %q", i, name, got[i].Name)
+				}
+			}
+			for i, uri := range tt.wantURIs {
+				if got[i].Location.URI != uri {
+					t.Errorf("symbol %d: want %q, got %q", i, uri, got[i].Location.URI)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/port/in"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/port/out"
+)
+
+type ShowHover struct {
+	workspaceRepository out.WorkspaceRepository
+	packageRepository   out.PackageRepository
+	documentRepository  out.DocumentRepository
+	violationRepository out.ViolationRepository
+	settingsRepository  out.SettingsRepository
+}
+
+func NewShowHover(
+	workspaceRepository out.WorkspaceRepository,
+	packageRepository out.PackageRepository,
+	documentRepository out.DocumentRepository,
+	violationRepository out.ViolationRepository,
+	settingsRepository out.SettingsRepository,
+) *ShowHover {
+	return &ShowHover{
+		workspaceRepository: workspaceRepository,
+		packageRepository:   packageRepository,
+		documentRepository:  documentRepository,
+		violationRepository: violationRepository,
+		settingsRepository:  settingsRepository,
+	}
+}
+
+// Hover explains a privacy violation under the cursor: which public folder the
+// constant's pack exposes and which public constants can be used instead.
+// Privacy violations of packs that are not loaded, and other violations when the diagnostics are shortened,
+// show the full message of packwerk.
+func (s *ShowHover) Hover(uri string, position domain.Position) (*domain.Hover, error) {
+	document, err := s.documentRepository.GetDocument(uri)
+	if err != nil {
+		return nil, err
+	}
+	workspace, err := s.workspaceRepository.GetWorkspace()
+	if err != nil {
+		return nil, err
+	}
+	violations, err := s.violationRepository.GetViolations()
+	if err != nil {
+		return nil, err
+	}
+	settings, err := s.settingsRepository.GetSettings()
+	if err != nil {
+		return nil, err
+	}
+	rules, err := settings.CompileRules()
+	if err != nil {
+		return nil, err
+	}
+
+	filePath := workspace.StripRootUri(uri)
+	lines := document.Lines()
+	for _, v := range violations {
+		if v.File != filePath {
+			continue
+		}
+		constantRange := referenceRange(lines, v)
+		if !constantRange.Contains(position) {
+			continue
+		}
+		if v.Kind() == "privacy" && v.ReferencedPackage != "" {
+			packageSet, err := s.packageRepository.GetPackageSet()
+			if err != nil {
+				return nil, err
+			}
+			pkg, ok := packageSet.Get(v.ReferencedPackage)
+			if !ok {
+				// The pack is missing from package_paths, so only packwerk can explain the violation
+				return &domain.Hover{Contents: fullExplanation(v), Range: constantRange}, nil
+			}
+			return &domain.Hover{
+				Contents: privacyExplanation(v, pkg, packageSet.PublicConstants(pkg.Name)),
+				Range:    constantRange,
+			}, nil
+		}
+		if _, reported := rules.Severity(v); !reported || !rules.ShortensMessages() {
+			continue
+		}
+		return &domain.Hover{Contents: fullExplanation(v), Range: constantRange}, nil
+	}
+
+	return nil, nil
+}
+
+// referenceRange covers the constant as written in the source, e.g. "Books::Inventory".
+// packwerk only reports where it starts, so fall back to a single character.
+func referenceRange(lines []string, v domain.Violation) domain.Range {
+	r := v.Range()
+	if int(r.Start.Line) >= len(lines) {
+		return r
+	}
+	line := lines[r.Start.Line]
+	end := int(r.Start.Character)
+	for end < len(line) && isConstantChar(line[end]) {
+		end++
+	}
+	if end > int(r.Start.Character) {
+		r.End.Character = uint32(end)
+	}
+	return r
+}
+
+func isConstantChar(c byte) bool {
+	return c == ':' || c == '_' || ('0' <= c && c <= '9') || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
+
+// fullExplanation shows the message of packwerk followed by its details.
+func fullExplanation(v domain.Violation) string {
+	var b strings.Builder
+	message := strings.TrimPrefix(v.Message, v.Type+": ")
+	fmt.Fprintf(&b, "**%s**: %s\n", v.Type, message)
+	if v.Details != "" {
+		fmt.Fprintf(&b, "\n%s\n", v.Details)
+	}
+	return b.String()
+}
+
+func privacyExplanation(v domain.Violation, pkg *domain.Package, constants []domain.PublicConstant) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "**Privacy violation**: `%s` is private to `%s`.\n\n", v.Constant, pkg.Name)
+	fmt.Fprintf(&b, "Reach it through the public folder `%s/`.\n", pkg.PublicDirectory())
+	if len(constants) == 0 {
+		fmt.Fprintf(&b, "\n`%s` does not expose any public constants yet.\n", pkg.Name)
+		return b.String()
+	}
+	fmt.Fprintf(&b, "\nPublic constants of `%s`:\n\n", pkg.Name)
+	for _, constant := range constants {
+		fmt.Fprintf(&b, "- `%s` (`%s`)\n", constant.Name, constant.File)
+	}
+	return b.String()
+}
+
+var _ in.ShowHover = (*ShowHover)(nil)
@@ -0,0 +1,154 @@
+package usecase
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/adapter/inmemory"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+)
+
+const testUserRb = `class User
+  def inventory
+    Books::Inventory.new(self)
+  end
+
+  def orders
+    Order.where(user: self)
+  end
+end
+`
+
+func TestShowHover_Hover(t *testing.T) {
+	workspaceRepository, packageRepository := setupTestProject(t)
+	workspace, _ := workspaceRepository.GetWorkspace()
+	documentRepository := inmemory.NewDocumentRepository()
+	violationRepository := inmemory.NewViolationRepository()
+	uc := NewShowHover(workspaceRepository, packageRepository, documentRepository, violationRepository, inmemory.NewSettingsRepository())
+
+	uri := workspace.BuildFileUri("packs/users/app/models/user.rb")
+	_ = documentRepository.Save(domain.NewDocument(uri, testUserRb))
+	_ = violationRepository.ReplaceAll([]domain.Violation{
+		{File: "packs/users/app/models/user.rb", Line: 3, Character: 4, Type: "Privacy violation", Constant: "::Books::Inventory", ReferencedPackage: "packs/books", ReferencingPackage: "packs/users"},
+		{File: "packs/users/app/models/user.rb", Line: 7, Character: 4, Type: "Privacy violation", Constant: "::Order", ReferencedPackage: "packs/orders", ReferencingPackage: "packs/users"},
+	})
+
+	t.Run("privacy violation", func(t *testing.T) {
+		got, err := uc.Hover(uri, domain.Position{Line: 2, Character: 12})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got == nil {
+			t.Fatal("expected a hover")
+		}
+		wantRange := domain.Range{Start: domain.Position{Line: 2, Character: 4}, End: domain.Position{Line: 2, Character: 20}}
+		if got.Range != wantRange {
+			t.Errorf("want range %+v, got %+v", wantRange, got.Range)
+		}
+		for _, want := range []string{
+			"`::Books::Inventory` is private to `packs/books`",
+			"public folder `packs/books/app/public/`",
+			"- `Books::Api` (`packs/books/app/public/books/api.rb`)",
+		} {
+			if !strings.Contains(got.Contents, want) {
+				t.Errorf("want contents to contain %q, got:\n%s", want, got.Contents)
+			}
+		}
+	})
+
+	t.Run("pack without public constants", func(t *testing.T) {
+		got, err := uc.Hover(uri, domain.Position{Line: 6, Character: 6})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got == nil || !strings.Contains(got.Contents, "`packs/orders` does not expose any public constants yet") {
+			t.Errorf("unexpected hover: %+v", got)
+		}
+	})
+
+	t.Run("outside of the reference", func(t *testing.T) {
+		got, err := uc.Hover(uri, domain.Position{Line: 2, Character: 26})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != nil {
+			t.Errorf("want no hover, got %+v", got)
+		}
+	})
+}
+
+func TestShowHover_Hover_FullMessage(t *testing.T) {
+	workspaceRepository, packageRepository := setupTestProject(t)
+	workspace, _ := workspaceRepository.GetWorkspace()
+	documentRepository := inmemory.NewDocumentRepository()
+	violationRepository := inmemory.NewViolationRepository()
+	settingsRepository := inmemory.NewSettingsRepository()
+	uc := NewShowHover(workspaceRepository, packageRepository, documentRepository, violationRepository, settingsRepository)
+
+	uri := workspace.BuildFileUri("packs/users/app/models/user.rb")
+	_ = documentRepository.Save(domain.NewDocument(uri, testUserRb))
+	_ = violationRepository.ReplaceAll([]domain.Violation{
+		{
+			File: "packs/users/app/models/user.rb", Line: 7, Character: 4, Type: "Dependency violation",
+			Message:  "Dependency violation: ::Order belongs to 'packs/orders', but 'packs/users' does not specify a dependency on 'packs/orders'.",
+			Details:  "Inference details: this is a reference to ::Order which seems to be defined in packs/orders/app/models/order.rb.",
+			Constant: "::Order", ReferencedPackage: "packs/orders", ReferencingPackage: "packs/users",
+		},
+	})
+	position := domain.Position{Line: 6, Character: 6}
+
+	got, err := uc.Hover(uri, position)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != nil {
+		t.Errorf("want no hover while diagnostics show the full message, got %+v", got)
+	}
+
+	settings := domain.NewSettings()
+	settings.MessageTemplate = domain.MessageTemplateShort
+	_ = settingsRepository.Save(settings)
+	got, err = uc.Hover(uri, position)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got == nil {
+		t.Fatal("expected a hover")
+	}
+	want := "**Dependency violation**: ::Order belongs to 'packs/orders', but 'packs/users' does not specify a dependency on 'packs/orders'.\n\n" +
+		"Inference details: this is a reference to ::Order which seems to be defined in packs/orders/app/models/order.rb.\n"
+	if got.Contents != want {
+		t.Errorf("unexpected contents:\n--- got ---\n%s\n--- want ---\n%s", got.Contents, want)
+	}
+}
+
+func TestShowHover_Hover_UnknownPack(t *testing.T) {
+	workspaceRepository, packageRepository := setupTestProject(t)
+	workspace, _ := workspaceRepository.GetWorkspace()
+	documentRepository := inmemory.NewDocumentRepository()
+	violationRepository := inmemory.NewViolationRepository()
+	uc := NewShowHover(workspaceRepository, packageRepository, documentRepository, violationRepository, inmemory.NewSettingsRepository())
+
+	// packs/legacy is not listed in package_paths
+	uri := workspace.BuildFileUri("packs/users/app/models/user.rb")
+	_ = documentRepository.Save(domain.NewDocument(uri, testUserRb))
+	_ = violationRepository.ReplaceAll([]domain.Violation{
+		{
+			File: "packs/users/app/models/user.rb", Line: 7, Character: 4, Type: "Privacy violation",
+			Message:  "Privacy violation: '::Order' is private to 'packs/legacy' but referenced from 'packs/users'.",
+			Constant: "::Order", ReferencedPackage: "packs/legacy", ReferencingPackage: "packs/users",
+		},
+	})
+
+	got, err := uc.Hover(uri, domain.Position{Line: 6, Character: 6})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got == nil {
+		t.Fatal("expected a hover")
+	}
+	want := "**Privacy violation**: '::Order' is private to 'packs/legacy' but referenced from 'packs/users'.\n"
+	if got.Contents != want {
+		t.Errorf("unexpected contents:\n--- got ---\n%s\n--- want ---\n%s", got.Contents, want)
+	}
+}
@@ -0,0 +1,31 @@
+package usecase
+
+import (
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/port/in"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/port/out"
+)
+
+// SyncDocument keeps the content of the documents opened in the client,
+// so features can work on unsaved edits.
+type SyncDocument struct {
+	documentRepository out.DocumentRepository
+}
+
+func NewSyncDocument(documentRepository out.DocumentRepository) *SyncDocument {
+	return &SyncDocument{documentRepository: documentRepository}
+}
+
+func (s *SyncDocument) Open(uri string, text string) error {
+	return s.documentRepository.Save(domain.NewDocument(uri, text))
+}
+
+func (s *SyncDocument) Change(uri string, text string) error {
+	return s.documentRepository.Save(domain.NewDocument(uri, text))
+}
+
+func (s *SyncDocument) Close(uri string) error {
+	return s.documentRepository.Delete(uri)
+}
+
+var _ in.SyncDocument = (*SyncDocument)(nil)
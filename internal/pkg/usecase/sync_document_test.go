@@ -0,0 +1,34 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/adapter/inmemory"
+)
+
+func TestSyncDocument(t *testing.T) {
+	repo := inmemory.NewDocumentRepository()
+	uc := NewSyncDocument(repo)
+	uri := "file:///root/packs/users/package.yml"
+
+	if err := uc.Open(uri, "dependencies:\n"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := uc.Change(uri, "dependencies:\n  - packs/books\n"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	document, err := repo.GetDocument(uri)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if document.Text != "dependencies:\n  - packs/books\n" {
+		t.Errorf("expected changed text, got %q", document.Text)
+	}
+
+	if err := uc.Close(uri); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := repo.GetDocument(uri); err == nil {
+		t.Error("expected document to be removed on close")
+	}
+}
@@ -0,0 +1,4 @@
+module Books
+  class Inventory
+  end
+end
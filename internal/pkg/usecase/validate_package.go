@@ -0,0 +1,285 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/port/in"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/port/out"
+)
+
+// ValidatePackage is a native equivalent of `packwerk validate` for package.yml and packwerk.yml.
+// It also checks .wpks-ls.yml.
+type ValidatePackage struct {
+	workspaceRepository out.WorkspaceRepository
+	packageRepository   out.PackageRepository
+	documentRepository  out.DocumentRepository
+	yamlParser          out.YamlParser
+	configReader        out.PackwerkConfigReader
+}
+
+func NewValidatePackage(
+	workspaceRepository out.WorkspaceRepository,
+	packageRepository out.PackageRepository,
+	documentRepository out.DocumentRepository,
+	yamlParser out.YamlParser,
+	configReader out.PackwerkConfigReader,
+) *ValidatePackage {
+	return &ValidatePackage{
+		workspaceRepository: workspaceRepository,
+		packageRepository:   packageRepository,
+		documentRepository:  documentRepository,
+		yamlParser:          yamlParser,
+		configReader:        configReader,
+	}
+}
+
+// Validate checks the opened config documents. Every given URI gets an entry,
+// so fixed problems are cleared in the client.
+func (v *ValidatePackage) Validate(context context.Context, uris ...string) (map[string][]domain.Diagnostic, error) {
+	if len(uris) == 0 {
+		return map[string][]domain.Diagnostic{}, nil
+	}
+
+	workspace, err := v.workspaceRepository.GetWorkspace()
+	if err != nil {
+		return nil, err
+	}
+	packageSet, err := v.packageRepository.GetPackageSet()
+	if err != nil {
+		return nil, err
+	}
+
+	allDiagnostics := make(map[string][]domain.Diagnostic, len(uris))
+	for _, uri := range uris {
+		document, err := v.documentRepository.GetDocument(uri)
+		if err != nil {
+			// Closed documents are not validated
+			continue
+		}
+
+		root, err := v.yamlParser.Parse(document.Text)
+		if err != nil {
+			var syntaxError *domain.YamlSyntaxError
+			if !errors.As(err, &syntaxError) {
+				return nil, err
+			}
+			allDiagnostics[uri] = []domain.Diagnostic{syntaxDiagnostic(syntaxError)}
+			continue
+		}
+
+		switch {
+		case document.IsPackageConfig():
+			current := packageSet.PackageOf(workspace.StripRootUri(uri))
+			diagnostics := validatePackageConfig(root, current.Name, packageSet)
+			diagnostics = append(diagnostics, v.detectCycles(root, current.Name, packageSet, workspace)...)
+			allDiagnostics[uri] = diagnostics
+		case document.IsPackwerkConfig():
+			allDiagnostics[uri] = validatePackwerkConfig(root)
+		case document.IsProjectSettings() && workspace.StripRootUri(uri) == domain.ProjectSettingsFile:
+			allDiagnostics[uri] = validateProjectSettings(root)
+		}
+	}
+
+	return allDiagnostics, nil
+}
+
+// ValidateAll checks every opened package.yml, packwerk.yml and .wpks-ls.yml.
+func (v *ValidatePackage) ValidateAll(context context.Context) (map[string][]domain.Diagnostic, error) {
+	documents, err := v.documentRepository.GetDocuments()
+	if err != nil {
+		return nil, err
+	}
+	uris := make([]string, 0, len(documents))
+	for _, document := range documents {
+		if domain.IsConfigFile(document.URI) || domain.IsProjectSettingsFile(document.URI) {
+			uris = append(uris, document.URI)
+		}
+	}
+	return v.Validate(context, uris...)
+}
+
+func validatePackageConfig(root *domain.YamlNode, name string, packageSet *domain.PackageSet) []domain.Diagnostic {
+	diagnostics := []domain.Diagnostic{}
+
+	for _, key := range enforcementKeys {
+		node := root.Get(key)
+		if node == nil || node.Kind != domain.YamlScalar {
+			continue
+		}
+		if !domain.Enforcement(node.Value).IsValid() {
+			diagnostics = append(diagnostics, validationDiagnostic(node.Range,
+				"Invalid '%s' value '%s'. Expected true, false or strict.", key, node.Value))
+		}
+	}
+
+	layer := ""
+	if node := root.Get(domain.KeyLayer); node.ScalarValue() != "" {
+		layer = node.Value
+		if packageSet.Config.LayerIndex(layer) < 0 {
+			diagnostics = append(diagnostics, validationDiagnostic(node.Range,
+				"Invalid 'layer' option '%s'. Layers must be one of %v, as configured in packwerk.yml.", layer, packageSet.Config.Layers))
+			layer = ""
+		}
+	}
+	enforceLayers := domain.Enforcement(root.Get(domain.KeyEnforceLayers).ScalarValue()).IsEnabled()
+
+	for _, item := range root.Get(domain.KeyDependencies).Items() {
+		if item.Value == name {
+			diagnostics = append(diagnostics, validationDiagnostic(item.Range,
+				"'%s' cannot depend on itself.", name))
+			continue
+		}
+		dependency, ok := packageSet.Get(item.Value)
+		if !ok {
+			diagnostics = append(diagnostics, validationDiagnostic(item.Range,
+				"Invalid 'dependencies': '%s' is not a known package.", item.Value))
+			continue
+		}
+		if enforceLayers && layer != "" && packageSet.Config.LayerIndex(dependency.Layer) >= 0 &&
+			packageSet.Config.LayerIndex(dependency.Layer) < packageSet.Config.LayerIndex(layer) {
+			diagnostics = append(diagnostics, validationDiagnostic(item.Range,
+				"'%s' has a layer type of '%s', which cannot rely on '%s', which has a layer type of '%s'.",
+				name, layer, dependency.Name, dependency.Layer))
+		}
+	}
+
+	for _, item := range root.Get(domain.KeyVisibleTo).Items() {
+		if _, ok := packageSet.Get(item.Value); !ok {
+			diagnostics = append(diagnostics, validationDiagnostic(item.Range,
+				"Invalid 'visible_to': '%s' is not a known package.", item.Value))
+		}
+	}
+
+	return diagnostics
+}
+
+// detectCycles reports every dependency of the pack that is part of a cycle.
+// The pack's own dependencies are taken from the document, so unsaved edits count.
+// The related information points at the dependencies closing the cycle in the other package.yml files.
+func (v *ValidatePackage) detectCycles(root *domain.YamlNode, name string, packageSet *domain.PackageSet, workspace *domain.Workspace) []domain.Diagnostic {
+	diagnostics := []domain.Diagnostic{}
+
+	items := root.Get(domain.KeyDependencies).Items()
+	graph := packageSet.DependencyGraph()
+	graph[name] = nil
+	for _, item := range items {
+		graph[name] = append(graph[name], item.Value)
+	}
+
+	component := map[string]int{}
+	for i, cycle := range graph.Cycles() {
+		for _, pack := range cycle {
+			component[pack] = i
+		}
+	}
+	current, ok := component[name]
+	if !ok {
+		return diagnostics
+	}
+
+	for _, item := range items {
+		if index, ok := component[item.Value]; !ok || index != current || item.Value == name {
+			continue
+		}
+		path := graph.CyclePath(name, item.Value)
+		if path == nil {
+			continue
+		}
+
+		diagnostic := validationDiagnostic(item.Range, "Dependency cycle: %s", strings.Join(path, " → "))
+		for i := 0; i+1 < len(path)-1; i++ {
+			pkg, _ := packageSet.Get(path[i+1])
+			diagnostic.RelatedInformation = append(diagnostic.RelatedInformation, domain.DiagnosticRelatedInformation{
+				Location: domain.Location{URI: workspace.BuildFileUri(pkg.ConfigPath()), Range: v.dependencyRange(workspace, pkg, path[i+2])},
+				Message:  fmt.Sprintf("'%s' depends on '%s'", path[i+1], path[i+2]),
+			})
+		}
+		diagnostics = append(diagnostics, diagnostic)
+	}
+
+	return diagnostics
+}
+
+// dependencyRange returns the range of the dependency in the package.yml of the pack,
+// or the start of the file when it cannot be read.
+func (v *ValidatePackage) dependencyRange(workspace *domain.Workspace, pkg *domain.Package, dependency string) domain.Range {
+	root, err := v.configReader.ReadPackageNode(workspace.RootPath, pkg)
+	if err != nil {
+		return domain.Range{}
+	}
+	for _, item := range root.Get(domain.KeyDependencies).Items() {
+		if item.Value == dependency {
+			return item.Range
+		}
+	}
+	return domain.Range{}
+}
+
+func validatePackwerkConfig(root *domain.YamlNode) []domain.Diagnostic {
+	diagnostics := []domain.Diagnostic{}
+
+	if node := root.Get("cache"); node != nil && node.Kind == domain.YamlScalar && node.Value != "true" && node.Value != "false" {
+		diagnostics = append(diagnostics, validationDiagnostic(node.Range,
+			"Invalid 'cache' value '%s'. Expected true or false.", node.Value))
+	}
+
+	for _, key := range []string{"include", "exclude", "package_paths"} {
+		node := root.Get(key)
+		if node == nil {
+			continue
+		}
+		if node.Kind == domain.YamlMapping || (node.Kind == domain.YamlSequence && len(node.Items()) != len(node.Children)) {
+			diagnostics = append(diagnostics, validationDiagnostic(node.KeyRange,
+				"Invalid '%s': expected a glob or a list of globs.", key))
+		}
+	}
+
+	var seen []string
+	for _, item := range root.Get("layers").Items() {
+		if slices.Contains(seen, item.Value) {
+			diagnostics = append(diagnostics, validationDiagnostic(item.Range,
+				"Layer '%s' is listed more than once.", item.Value))
+			continue
+		}
+		seen = append(seen, item.Value)
+	}
+
+	return diagnostics
+}
+
+func validateProjectSettings(root *domain.YamlNode) []domain.Diagnostic {
+	_, settingsErrors := domain.ApplyProjectSettings(root, domain.NewSettings())
+	diagnostics := make([]domain.Diagnostic, 0, len(settingsErrors))
+	for _, settingsError := range settingsErrors {
+		diagnostics = append(diagnostics, validationDiagnostic(settingsError.Range, "%s", settingsError.Message))
+	}
+	return diagnostics
+}
+
+func syntaxDiagnostic(err *domain.YamlSyntaxError) domain.Diagnostic {
+	return domain.Diagnostic{
+		Range: domain.Range{
+			Start: domain.Position{Line: err.Line, Character: 0},
+			End:   domain.Position{Line: err.Line + 1, Character: 0},
+		},
+		Severity: domain.SeverityError,
+		Source:   packwerkSource,
+		Message:  "Invalid YAML: " + err.Message,
+	}
+}
+
+func validationDiagnostic(r domain.Range, format string, args ...any) domain.Diagnostic {
+	return domain.Diagnostic{
+		Range:    r,
+		Severity: domain.SeverityError,
+		Source:   packwerkSource,
+		Message:  fmt.Sprintf(format, args...),
+	}
+}
+
+var _ in.ValidatePackage = (*ValidatePackage)(nil)
@@ -0,0 +1,216 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/adapter/inmemory"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/adapter/packwerk/config"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+)
+
+func TestValidatePackage_Validate(t *testing.T) {
+	workspaceRepository, packageRepository := setupTestProject(t)
+	workspace, _ := workspaceRepository.GetWorkspace()
+	documentRepository := inmemory.NewDocumentRepository()
+	uc := NewValidatePackage(workspaceRepository, packageRepository, documentRepository, config.NewYamlParser(), config.NewReader())
+
+	tests := []struct {
+		name      string
+		path      string
+		text      string
+		wantLines []uint32
+		wantFirst string
+	}{
+		{
+			name:      "valid package.yml",
+			path:      "packs/users/package.yml",
+			text:      "enforce_dependencies: true\nlayer: product\ndependencies:\n  - packs/books\n",
+			wantLines: []uint32{},
+		},
+		{
+			name:      "unknown dependency",
+			path:      "packs/users/package.yml",
+			text:      "dependencies:\n  - packs/books\n  - packs/missing\n",
+			wantLines: []uint32{2},
+			wantFirst: "Invalid 'dependencies': 'packs/missing' is not a known package.",
+		},
+		{
+			name:      "self dependency",
+			path:      "packs/users/package.yml",
+			text:      "dependencies:\n  - packs/users\n",
+			wantLines: []uint32{1},
+			wantFirst: "'packs/users' cannot depend on itself.",
+		},
+		{
+			name:      "invalid enforcement value",
+			path:      "packs/users/package.yml",
+			text:      "enforce_privacy: sometimes\n",
+			wantLines: []uint32{0},
+			wantFirst: "Invalid 'enforce_privacy' value 'sometimes'. Expected true, false or strict.",
+		},
+		{
+			name:      "unknown layer",
+			path:      "packs/users/package.yml",
+			text:      "layer: platform\n",
+			wantLines: []uint32{0},
+			wantFirst: "Invalid 'layer' option 'platform'. Layers must be one of [product utility], as configured in packwerk.yml.",
+		},
+		{
+			name:      "dependency breaking the layer order",
+			path:      "packs/books/package.yml",
+			text:      "enforce_layers: true\nlayer: utility\ndependencies:\n  - packs/orders\n",
+			wantLines: []uint32{3},
+			wantFirst: "'packs/books' has a layer type of 'utility', which cannot rely on 'packs/orders', which has a layer type of 'product'.",
+		},
+		{
+			name:      "layer order is not checked when not enforced",
+			path:      "packs/books/package.yml",
+			text:      "layer: utility\ndependencies:\n  - packs/orders\n",
+			wantLines: []uint32{},
+		},
+		{
+			name:      "unknown visible_to",
+			path:      "packs/books/package.yml",
+			text:      "visible_to:\n  - packs/missing\n",
+			wantLines: []uint32{1},
+			wantFirst: "Invalid 'visible_to': 'packs/missing' is not a known package.",
+		},
+		{
+			name:      "syntax error",
+			path:      "packs/users/package.yml",
+			text:      "dependencies: [\n",
+			wantLines: []uint32{0},
+		},
+		{
+			name:      "duplicated layer in packwerk.yml",
+			path:      "packwerk.yml",
+			text:      "layers:\n  - product\n  - product\n",
+			wantLines: []uint32{2},
+			wantFirst: "Layer 'product' is listed more than once.",
+		},
+		{
+			name:      "invalid cache in packwerk.yml",
+			path:      "packwerk.yml",
+			text:      "cache: sometimes\npackage_paths:\n  nested: true\n",
+			wantLines: []uint32{0, 1},
+			wantFirst: "Invalid 'cache' value 'sometimes'. Expected true or false.",
+		},
+		{
+			name:      "invalid .wpks-ls.yml",
+			path:      ".wpks-ls.yml",
+			text:      "severity:\n  privacy: warning\n  dependency: fatal\n",
+			wantLines: []uint32{2},
+			wantFirst: "Invalid severity 'fatal'. Expected error, warning, information, hint or ignore.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uri := workspace.BuildFileUri(tt.path)
+			_ = documentRepository.Save(domain.NewDocument(uri, tt.text))
+
+			got, err := uc.Validate(context.Background(), uri)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			diagnostics, ok := got[uri]
+			if !ok {
+				t.Fatalf("expected an entry for %s", uri)
+			}
+			if len(diagnostics) != len(tt.wantLines) {
+				t.Fatalf("want %d diagnostics, got %+v", len(tt.wantLines), diagnostics)
+			}
+			for i, line := range tt.wantLines {
+				if diagnostics[i].Range.Start.Line != line {
+					t.Errorf("diagnostic %d: want line %d, got %d", i, line, diagnostics[i].Range.Start.Line)
+				}
+			}
+			if tt.wantFirst != "" && diagnostics[0].Message != tt.wantFirst {
+				t.Errorf("want message %q, got %q", tt.wantFirst, diagnostics[0].Message)
+			}
+		})
+	}
+}
+
+func TestValidatePackage_Validate_ClosedDocument(t *testing.T) {
+	workspaceRepository, packageRepository := setupTestProject(t)
+	uc := NewValidatePackage(workspaceRepository, packageRepository, inmemory.NewDocumentRepository(), config.NewYamlParser(), config.NewReader())
+
+	got, err := uc.Validate(context.Background(), "file:///root/packs/users/package.yml")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("expected no diagnostics for closed documents, got %+v", got)
+	}
+}
+
+func TestValidatePackage_ValidateAll(t *testing.T) {
+	workspaceRepository, packageRepository := setupTestProject(t)
+	workspace, _ := workspaceRepository.GetWorkspace()
+	documentRepository := inmemory.NewDocumentRepository()
+	uc := NewValidatePackage(workspaceRepository, packageRepository, documentRepository, config.NewYamlParser(), config.NewReader())
+
+	usersURI := workspace.BuildFileUri("packs/users/package.yml")
+	packwerkURI := workspace.BuildFileUri("packwerk.yml")
+	rubyURI := workspace.BuildFileUri("packs/users/app/models/user.rb")
+	_ = documentRepository.Save(domain.NewDocument(usersURI, "layer: platform\n"))
+	_ = documentRepository.Save(domain.NewDocument(packwerkURI, "cache: false\n"))
+	_ = documentRepository.Save(domain.NewDocument(rubyURI, "class User\nend\n"))
+	settingsURI := workspace.BuildFileUri(".wpks-ls.yml")
+	_ = documentRepository.Save(domain.NewDocument(settingsURI, "severity: warning\n"))
+
+	got, err := uc.ValidateAll(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("want the 3 config files to be validated, got %+v", got)
+	}
+	if len(got[usersURI]) != 1 || len(got[packwerkURI]) != 0 {
+		t.Errorf("unexpected diagnostics: %+v", got)
+	}
+}
+
+func TestValidatePackage_Validate_DependencyCycle(t *testing.T) {
+	workspaceRepository, packageRepository := setupTestProject(t)
+	workspace, _ := workspaceRepository.GetWorkspace()
+	documentRepository := inmemory.NewDocumentRepository()
+	uc := NewValidatePackage(workspaceRepository, packageRepository, documentRepository, config.NewYamlParser(), config.NewReader())
+
+	// packs/users already depends on packs/books on disk
+	uri := workspace.BuildFileUri("packs/books/package.yml")
+	_ = documentRepository.Save(domain.NewDocument(uri, "dependencies:\n  - .\n  - packs/users\n"))
+
+	got, err := uc.Validate(context.Background(), uri)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	diagnostics := got[uri]
+	if len(diagnostics) != 1 {
+		t.Fatalf("want 1 diagnostic, got %+v", diagnostics)
+	}
+
+	d := diagnostics[0]
+	if d.Range.Start.Line != 2 {
+		t.Errorf("want diagnostic on line 2, got %d", d.Range.Start.Line)
+	}
+	if want := "Dependency cycle: packs/books → packs/users → packs/books"; d.Message != want {
+		t.Errorf("want message %q, got %q", want, d.Message)
+	}
+	if len(d.RelatedInformation) != 1 {
+		t.Fatalf("want 1 related information, got %+v", d.RelatedInformation)
+	}
+	related := d.RelatedInformation[0]
+	if related.Location.URI != workspace.BuildFileUri("packs/users/package.yml") {
+		t.Errorf("unexpected related location: %s", related.Location.URI)
+	}
+	if want := "'packs/users' depends on 'packs/books'"; related.Message != want {
+		t.Errorf("want related message %q, got %q", want, related.Message)
+	}
+	want := domain.Range{Start: domain.Position{Line: 3, Character: 4}, End: domain.Position{Line: 3, Character: 15}}
+	if related.Location.Range != want {
+		t.Errorf("want the related range on the dependency %+v, got %+v", want, related.Location.Range)
+	}
+}
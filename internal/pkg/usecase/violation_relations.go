@@ -0,0 +1,152 @@
+package usecase
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/domain"
+	"github.com/rinsyan0518/wpks-ls/internal/pkg/port/out"
+)
+
+// violationRelations links violations to the places that explain them:
+// the definition of the constant and the package.yml lines that make the reference a violation.
+// Files are read at most once per instance, so create one per batch of diagnostics.
+type violationRelations struct {
+	workspace        *domain.Workspace
+	packageSet       *domain.PackageSet // nil when packages are not loaded
+	configReader     out.PackwerkConfigReader
+	fileSystem       out.FileSystem
+	packageNodes     map[string]*domain.YamlNode
+	definitionRanges map[string]domain.Range
+}
+
+func newViolationRelations(
+	workspace *domain.Workspace,
+	packageRepository out.PackageRepository,
+	configReader out.PackwerkConfigReader,
+	fileSystem out.FileSystem,
+) *violationRelations {
+	packageSet, err := packageRepository.GetPackageSet()
+	if err != nil {
+		// Without packages only the definition can be linked
+		packageSet = nil
+	}
+	return &violationRelations{
+		workspace:        workspace,
+		packageSet:       packageSet,
+		configReader:     configReader,
+		fileSystem:       fileSystem,
+		packageNodes:     make(map[string]*domain.YamlNode),
+		definitionRanges: make(map[string]domain.Range),
+	}
+}
+
+// Of returns the related information of the violation, or nil when nothing is known.
+func (r *violationRelations) Of(v domain.Violation) []domain.DiagnosticRelatedInformation {
+	var related []domain.DiagnosticRelatedInformation
+	if info, ok := r.definition(v); ok {
+		related = append(related, info)
+	}
+	if v.Kind() == "dependency" {
+		if info, ok := r.dependencies(v); ok {
+			related = append(related, info)
+		}
+	}
+	if info, ok := r.enforcement(v); ok {
+		related = append(related, info)
+	}
+	return related
+}
+
+// definition points at the class, module or assignment defining the constant.
+func (r *violationRelations) definition(v domain.Violation) (domain.DiagnosticRelatedInformation, bool) {
+	if v.DefinitionFile == "" {
+		return domain.DiagnosticRelatedInformation{}, false
+	}
+	key := v.DefinitionFile + "\x00" + v.Constant
+	definitionRange, ok := r.definitionRanges[key]
+	if !ok {
+		text, exists, err := r.fileSystem.ReadFile(filepath.Join(r.workspace.RootPath, filepath.FromSlash(v.DefinitionFile)))
+		if err == nil && exists {
+			// Falls back to the top of the file when the definition is not found
+			definitionRange, _ = domain.FindConstantDefinition(text, v.Constant)
+		}
+		r.definitionRanges[key] = definitionRange
+	}
+	return domain.DiagnosticRelatedInformation{
+		Location: domain.Location{URI: r.workspace.BuildFileUri(v.DefinitionFile), Range: definitionRange},
+		Message:  fmt.Sprintf("%s is defined here", v.Constant),
+	}, true
+}
+
+// dependencies points at the dependencies of the referencing pack, which lack the referenced pack.
+func (r *violationRelations) dependencies(v domain.Violation) (domain.DiagnosticRelatedInformation, bool) {
+	pkg, root, ok := r.packageNode(v.ReferencingPackage)
+	if !ok {
+		return domain.DiagnosticRelatedInformation{}, false
+	}
+	location := domain.Location{URI: r.workspace.BuildFileUri(pkg.ConfigPath())}
+	message := fmt.Sprintf("'%s' declares no dependencies", pkg.Name)
+	if node := root.Get(domain.KeyDependencies); node != nil {
+		location.Range = node.KeyRange
+		message = fmt.Sprintf("'%s' does not list '%s' in its dependencies", pkg.Name, v.ReferencedPackage)
+	}
+	return domain.DiagnosticRelatedInformation{Location: location, Message: message}, true
+}
+
+// enforcement points at the enforce_* flag turning on the checker that reported the violation.
+func (r *violationRelations) enforcement(v domain.Violation) (domain.DiagnosticRelatedInformation, bool) {
+	name, key := v.ReferencedPackage, ""
+	switch v.Kind() {
+	case "dependency":
+		name, key = v.ReferencingPackage, domain.KeyEnforceDependencies
+	case "layer":
+		name, key = v.ReferencingPackage, domain.KeyEnforceLayers
+	case "privacy":
+		key = domain.KeyEnforcePrivacy
+	case "visibility":
+		key = domain.KeyEnforceVisibility
+	case "folder_privacy":
+		key = domain.KeyEnforceFolderPrivacy
+	default:
+		return domain.DiagnosticRelatedInformation{}, false
+	}
+
+	pkg, root, ok := r.packageNode(name)
+	if !ok {
+		return domain.DiagnosticRelatedInformation{}, false
+	}
+	node := root.Get(key)
+	if node == nil {
+		return domain.DiagnosticRelatedInformation{}, false
+	}
+	return domain.DiagnosticRelatedInformation{
+		Location: domain.Location{URI: r.workspace.BuildFileUri(pkg.ConfigPath()), Range: node.Extent()},
+		Message:  fmt.Sprintf("'%s' sets %s: %s", pkg.Name, key, node.ScalarValue()),
+	}, true
+}
+
+// packageNode returns the parsed package.yml of the pack.
+func (r *violationRelations) packageNode(name string) (*domain.Package, *domain.YamlNode, bool) {
+	if r.packageSet == nil || name == "" {
+		return nil, nil, false
+	}
+	pkg, ok := r.packageSet.Get(name)
+	if !ok {
+		return nil, nil, false
+	}
+	root, ok := r.packageNodes[name]
+	if !ok {
+		var err error
+		root, err = r.configReader.ReadPackageNode(r.workspace.RootPath, pkg)
+		if err != nil {
+			// A broken package.yml is reported by validation, not here
+			root = nil
+		}
+		r.packageNodes[name] = root
+	}
+	if root == nil {
+		return nil, nil, false
+	}
+	return pkg, root, true
+}
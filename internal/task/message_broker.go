@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"sync"
 	"sync/atomic"
+	"time"
 )
 
 // brokerState represents the state of the message broker
@@ -21,7 +22,10 @@ const (
 type Broker[T any] interface {
 	RegisterTopic(topic string, handler JobFunc[T], opts ...WorkerConfigOption)
 	Enqueue(topic string, message T)
+	TryEnqueue(topic string, message T) bool
+	SetBatchConfig(topic string, size int, timeout time.Duration)
 	Start(ctx context.Context)
+	Stop()
 	Close()
 }
 
@@ -33,6 +37,7 @@ type MessageBroker[T any] struct {
 	baseCtx context.Context
 	cancel  context.CancelFunc
 	wg      sync.WaitGroup
+	done    chan struct{} // closed once the workers finished after Stop
 }
 
 // NewMessageBroker creates a new topic-based message broker
@@ -74,6 +79,7 @@ func (b *MessageBroker[T]) Start(ctx context.Context) {
 
 	b.state.Store(int32(brokerStateRunning))
 	b.baseCtx, b.cancel = context.WithCancel(ctx)
+	b.done = make(chan struct{})
 
 	// Start all existing workers
 	for _, worker := range b.workers {
@@ -104,13 +110,48 @@ func (b *MessageBroker[T]) Enqueue(topic string, message T) {
 	}
 }
 
-// Close stops all topic workers and waits for them to finish
-func (b *MessageBroker[T]) Close() {
+// TryEnqueue adds a message like Enqueue, but returns false instead of panicking
+// when the broker is not running or the topic worker queue is full
+func (b *MessageBroker[T]) TryEnqueue(topic string, message T) bool {
+	// Holding the lock keeps Close from closing the queue between the state check and the send
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
 	if b.state.Load() != int32(brokerStateRunning) {
-		return
+		return false
 	}
 
+	worker, exists := b.workers[topic]
+	if !exists {
+		panic(fmt.Sprintf("topic not registered: %s", topic))
+	}
+
+	return worker.Enqueue(message)
+}
+
+// SetBatchConfig changes how the messages of a registered topic are batched, also while running
+func (b *MessageBroker[T]) SetBatchConfig(topic string, size int, timeout time.Duration) {
+	b.mu.RLock()
+	worker, exists := b.workers[topic]
+	b.mu.RUnlock()
+	if !exists {
+		panic(fmt.Sprintf("topic not registered: %s", topic))
+	}
+
+	worker.SetBatchConfig(size, timeout)
+}
+
+// Stop cancels the jobs in flight and closes the queues, without waiting for the workers to finish.
+// Jobs waiting for something that can only happen once the caller returns would block Close.
+func (b *MessageBroker[T]) Stop() {
+	// Concurrent calls and TryEnqueue see the broker draining before any queue is closed
+	b.mu.Lock()
+	if b.state.Load() != int32(brokerStateRunning) {
+		b.mu.Unlock()
+		return
+	}
 	b.state.Store(int32(brokerStateDraining))
+	b.mu.Unlock()
 
 	// Cancel context to stop all workers
 	if b.cancel != nil {
@@ -120,13 +161,27 @@ func (b *MessageBroker[T]) Close() {
 	// Close all worker queues
 	b.mu.RLock()
 	for _, worker := range b.workers {
-		worker.Close()
+		worker.Stop()
 	}
 	b.mu.RUnlock()
 
-	// Wait for all workers to finish
-	b.wg.Wait()
-	b.state.Store(int32(brokerStateClosed))
+	go func() {
+		b.wg.Wait()
+		b.state.Store(int32(brokerStateClosed))
+		close(b.done)
+	}()
+}
+
+// Close stops all topic workers and waits for them to finish
+func (b *MessageBroker[T]) Close() {
+	b.Stop()
+
+	b.mu.RLock()
+	done := b.done
+	b.mu.RUnlock()
+	if done != nil {
+		<-done
+	}
 }
 
 var _ Broker[any] = (*MessageBroker[any])(nil)
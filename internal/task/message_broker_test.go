@@ -339,6 +339,83 @@ func TestMessageBroker_WithQueueSizeConfig(t *testing.T) {
 	broker.Enqueue("small-queue-topic", message)
 }
 
+func TestMessageBroker_TryEnqueue(t *testing.T) {
+	type testData struct {
+		ID    int
+		Value string
+	}
+
+	release := make(chan struct{})
+	handler := func(ctx context.Context, msgs []testData) {
+		<-release
+	}
+
+	broker := NewMessageBroker[testData]()
+	broker.RegisterTopic("small-queue-topic", handler, WithQueueSize(1))
+
+	message := testData{ID: 1, Value: "test"}
+
+	// Should not panic before start
+	if broker.TryEnqueue("small-queue-topic", message) {
+		t.Error("Expected TryEnqueue to fail before start")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	broker.Start(ctx)
+
+	// The first message blocks the handler and the second one fills the queue
+	accepted := 0
+	for range 3 {
+		if broker.TryEnqueue("small-queue-topic", message) {
+			accepted++
+		}
+	}
+	if accepted == 3 {
+		t.Error("Expected TryEnqueue to fail when the queue is full")
+	}
+
+	close(release)
+	broker.Close()
+
+	// Should not panic after close
+	if broker.TryEnqueue("small-queue-topic", message) {
+		t.Error("Expected TryEnqueue to fail after close")
+	}
+}
+
+func TestMessageBroker_TryEnqueue_ConcurrentClose(t *testing.T) {
+	type testData struct {
+		ID    int
+		Value string
+	}
+
+	handler := func(ctx context.Context, msgs []testData) {}
+	broker := NewMessageBroker[testData]()
+	broker.RegisterTopic("test-topic", handler, WithQueueSize(1000))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	broker.Start(ctx)
+
+	// Should not panic with a send on a closed queue
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			for j := range 1000 {
+				broker.TryEnqueue("test-topic", testData{ID: id*1000 + j})
+			}
+		}(i)
+	}
+
+	broker.Close()
+	wg.Wait()
+}
+
 func TestMessageBroker_ConcurrentEnqueue(t *testing.T) {
 	type testData struct {
 		ID    int
@@ -419,6 +496,45 @@ func TestMessageBroker_Close_Idempotent(t *testing.T) {
 	broker.Close()
 }
 
+func TestMessageBroker_Stop(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	handler := func(ctx context.Context, msgs []int) {
+		close(started)
+		<-release
+	}
+
+	broker := NewMessageBroker[int]()
+	broker.RegisterTopic("test-topic", handler)
+	broker.Start(context.Background())
+	broker.Enqueue("test-topic", 1)
+	<-started
+
+	// Stop returns while the handler is still running
+	broker.Stop()
+	if broker.TryEnqueue("test-topic", 2) {
+		t.Error("Expected messages to be rejected after Stop")
+	}
+
+	closed := make(chan struct{})
+	go func() {
+		broker.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+		t.Fatal("Expected Close to wait for the running handler")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("Expected Close to return once the handler finished")
+	}
+}
+
 func TestMessageBroker_HandlerPanic(t *testing.T) {
 	type testData struct {
 		ID    int
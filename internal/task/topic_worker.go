@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"sync"
 	"time"
 )
 
@@ -18,6 +19,11 @@ type TopicWorker[T any] struct {
 	handler     JobFunc[T]
 	queue       chan T
 	done        chan struct{}
+
+	// batchMu guards the batch config waiting to be applied by Run
+	batchMu      sync.Mutex
+	pendingBatch *WorkerConfig
+	rebatch      chan struct{}
 }
 
 // NewTopicWorker creates a new topic worker
@@ -29,6 +35,7 @@ func NewTopicWorker[T any](topic string, handler JobFunc[T], config *WorkerConfi
 		handler:     handler,
 		config:      config,
 		done:        make(chan struct{}),
+		rebatch:     make(chan struct{}, 1),
 	}
 }
 
@@ -86,6 +93,22 @@ func (w *TopicWorker[T]) Run(ctx context.Context) {
 			// Timeout occurred, process accumulated batch
 			w.ProcessBatch(ctx)
 
+		case <-w.rebatch:
+			if timer != nil {
+				timer.Stop()
+				timer = nil
+			}
+			w.applyBatchConfig()
+			if w.config.Enabled && w.config.BatchTimeout > 0 {
+				timer = time.NewTimer(w.config.BatchTimeout)
+				if w.batchBuffer.Size() == 0 {
+					timer.Stop()
+				}
+			}
+			if w.ShouldProcessBatch() {
+				w.ProcessBatch(ctx)
+			}
+
 		case <-ctx.Done():
 			if timer != nil {
 				timer.Stop()
@@ -145,6 +168,49 @@ func (w *TopicWorker[T]) ProcessBatch(ctx context.Context) {
 	}()
 }
 
+// SetBatchConfig changes the batch size and timeout. The worker applies them before
+// the next message, keeping the messages it collected so far.
+func (w *TopicWorker[T]) SetBatchConfig(size int, timeout time.Duration) {
+	w.batchMu.Lock()
+	config := *w.config
+	if w.pendingBatch != nil {
+		config = *w.pendingBatch
+	}
+	WithBatchConfig(size, timeout)(&config)
+	w.pendingBatch = &config
+	w.batchMu.Unlock()
+
+	select {
+	case w.rebatch <- struct{}{}:
+	default: // Run has not picked up the previous change yet, and will see this one
+	}
+}
+
+// applyBatchConfig takes the config of SetBatchConfig, moving the collected messages
+// to a buffer sized for the new batch.
+func (w *TopicWorker[T]) applyBatchConfig() {
+	w.batchMu.Lock()
+	config := w.pendingBatch
+	w.pendingBatch = nil
+	if config != nil {
+		w.config = config
+	}
+	w.batchMu.Unlock()
+	if config == nil {
+		return
+	}
+
+	buffer := NewRingBuffer[T](max(config.BatchSize*10, w.batchBuffer.Size()))
+	for {
+		item, ok := w.batchBuffer.TryGet()
+		if !ok {
+			break
+		}
+		buffer.TryPut(item)
+	}
+	w.batchBuffer = buffer
+}
+
 // Enqueue adds an item to the worker's queue
 func (w *TopicWorker[T]) Enqueue(item T) bool {
 	select {
@@ -155,8 +221,13 @@ func (w *TopicWorker[T]) Enqueue(item T) bool {
 	}
 }
 
+// Stop closes the queue without waiting for the worker, which returns after the job in flight
+func (w *TopicWorker[T]) Stop() {
+	close(w.queue)
+}
+
 // Close closes the worker
 func (w *TopicWorker[T]) Close() {
-	close(w.queue)
+	w.Stop()
 	<-w.done
 }
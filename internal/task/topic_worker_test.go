@@ -256,6 +256,58 @@ func TestTopicWorker_TimeoutProcessing(t *testing.T) {
 	worker.Close()
 }
 
+func TestTopicWorker_SetBatchConfig(t *testing.T) {
+	batches := make(chan []string, 10)
+	handler := func(ctx context.Context, items []string) {
+		batches <- items
+	}
+
+	// The batch would wait for 10 items or a minute
+	config := NewWorkerConfig(
+		WithQueueSize(10),
+		WithBatchConfig(10, time.Minute),
+	)
+	worker := NewTopicWorker("rebatch-topic", handler, config)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	go worker.Run(ctx)
+
+	if !worker.Enqueue("item1") || !worker.Enqueue("item2") {
+		t.Fatal("Failed to enqueue items")
+	}
+	time.Sleep(20 * time.Millisecond)
+
+	// The collected items are kept and processed with the new timeout
+	worker.SetBatchConfig(10, 20*time.Millisecond)
+	select {
+	case batch := <-batches:
+		if len(batch) != 2 {
+			t.Errorf("Expected the 2 collected items, got %v", batch)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Timeout waiting for the new batch timeout")
+	}
+
+	// A smaller batch size applies to the next items
+	worker.SetBatchConfig(1, time.Minute)
+	time.Sleep(20 * time.Millisecond)
+	if !worker.Enqueue("item3") {
+		t.Fatal("Failed to enqueue item3")
+	}
+	select {
+	case batch := <-batches:
+		if len(batch) != 1 {
+			t.Errorf("Expected a batch of 1 item, got %v", batch)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Timeout waiting for the new batch size")
+	}
+
+	worker.Close()
+}
+
 func TestTopicWorker_DisabledBatching(t *testing.T) {
 	receivedBatches := make(chan []string, 10)
 